@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/tiwariParth/whosay/internal/collectors"
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+const procUsage = `Usage: whosay proc <action> [options] <pid|name>
+
+Actions:
+  signal    Send a signal (-s TERM, HUP, 9, ...)
+  kill      Send SIGTERM, then SIGKILL if the process is still running after -timeout
+  renice    Change the scheduling priority (-n -20..19)
+  ionice    Change the I/O scheduling class and level (-class idle|best-effort|realtime|none -level 0..7)
+  affinity  Restrict the process to a set of CPUs (-cpus 0-3,6)
+
+A name matches processes with exactly that name; with -cmdline, it matches every
+process whose name or command line contains it. whosay and the processes it was
+started from (sudo, a shell) are never targets. -json needs -yes or -dry-run.
+Options must come before the target.`
+
+// runProcCommand handles "whosay proc ..." and returns the process exit code
+func runProcCommand(args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		fmt.Println(procUsage)
+		return 2
+	}
+
+	action := args[0]
+	flags := flag.NewFlagSet("proc "+action, flag.ContinueOnError)
+	yesFlag := flags.Bool("yes", false, "Don't ask for confirmation")
+	dryRunFlag := flags.Bool("dry-run", false, "Show what would be done without doing it")
+	jsonFlag := flags.Bool("json", false, "Output results in JSON format (needs -yes or -dry-run)")
+	cmdlineFlag := flags.Bool("cmdline", false, "Match the target against command lines instead of exact process names")
+
+	var signalFlag, classFlag, cpusFlag *string
+	var niceFlag, levelFlag *int
+	var timeoutFlag *time.Duration
+
+	switch action {
+	case "signal":
+		signalFlag = flags.String("s", "TERM", "Signal name or number")
+	case "kill":
+		timeoutFlag = flags.Duration("timeout", 5*time.Second, "How long to wait after SIGTERM before sending SIGKILL")
+	case "renice":
+		niceFlag = flags.Int("n", 10, "Nice value, from -20 (highest priority) to 19 (lowest)")
+	case "ionice":
+		classFlag = flags.String("class", "best-effort", "I/O class: idle, best-effort, realtime or none")
+		levelFlag = flags.Int("level", 4, "Priority level within the class, from 0 (highest) to 7 (lowest)")
+	case "affinity":
+		cpusFlag = flags.String("cpus", "", "CPU list, e.g. 0-3,6")
+	default:
+		fmt.Printf("Error: unknown proc action '%s'\n\n%s\n", action, procUsage)
+		return 2
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		fmt.Printf("Error: proc %s needs exactly one target (a PID or process name)\n", action)
+		return 2
+	}
+
+	// The confirmation prompt would mix with the JSON, and JSON output hides the targets
+	if *jsonFlag && !*yesFlag && !*dryRunFlag {
+		fmt.Println("Error: -json needs -yes or -dry-run, as it has no confirmation prompt")
+		return 2
+	}
+
+	// Validate arguments before touching any process
+	var describe string
+	var apply func(pid int) (string, error)
+
+	switch action {
+	case "signal":
+		if _, err := collectors.ParseSignal(*signalFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 2
+		}
+		describe = fmt.Sprintf("send SIG%s", strings.TrimPrefix(strings.ToUpper(*signalFlag), "SIG"))
+		apply = func(pid int) (string, error) {
+			return "", collectors.SendSignal(pid, *signalFlag)
+		}
+	case "kill":
+		describe = fmt.Sprintf("terminate (SIGKILL after %s)", *timeoutFlag)
+		apply = func(pid int) (string, error) {
+			killed, err := collectors.TerminateProcess(pid, *timeoutFlag)
+			if killed {
+				return "killed after timeout", err
+			}
+			return "terminated", err
+		}
+	case "renice":
+		if err := collectors.ValidateNice(*niceFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 2
+		}
+		describe = fmt.Sprintf("set nice value to %d", *niceFlag)
+		apply = func(pid int) (string, error) {
+			return "", collectors.ReniceProcess(pid, *niceFlag)
+		}
+	case "ionice":
+		if _, err := collectors.ParseIOPriority(*classFlag, *levelFlag); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return 2
+		}
+		describe = fmt.Sprintf("set I/O priority to %s level %d", *classFlag, *levelFlag)
+		apply = func(pid int) (string, error) {
+			return "", collectors.SetIOPriority(pid, *classFlag, *levelFlag)
+		}
+	case "affinity":
+		cpus, err := collectors.ParseCPUList(*cpusFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid -cpus value: %v\n", err)
+			return 2
+		}
+		describe = fmt.Sprintf("restrict to CPUs %s", *cpusFlag)
+		apply = func(pid int) (string, error) {
+			return "", collectors.SetCPUAffinity(pid, cpus)
+		}
+	}
+
+	targets, err := collectors.ResolveProcessTargets(flags.Arg(0), *cmdlineFlag)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	if !*jsonFlag {
+		fmt.Printf("About to %s for %d process(es):\n", describe, len(targets))
+		for _, proc := range targets {
+			command := proc.CommandLine
+			if len(command) > 60 {
+				command = command[:57] + "..."
+			}
+			fmt.Printf("  %-7d %-16s %-10s %s\n", proc.PID, proc.Name, proc.User, command)
+		}
+	}
+
+	if !*dryRunFlag && !*yesFlag && !confirm("Proceed?") {
+		fmt.Println("Aborted")
+		return 1
+	}
+
+	results := make([]models.ProcessActionResult, 0, len(targets))
+	failed := false
+	for _, proc := range targets {
+		result := models.ProcessActionResult{
+			PID:    proc.PID,
+			Name:   proc.Name,
+			Action: describe,
+			DryRun: *dryRunFlag,
+		}
+
+		if *dryRunFlag {
+			result.Success = true
+		} else {
+			detail, err := apply(proc.PID)
+			result.Detail = detail
+			result.Success = err == nil
+			if err != nil {
+				result.Error = err.Error()
+				failed = true
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if *jsonFlag {
+		jsonData, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(jsonData))
+	} else {
+		printProcActionResults(results)
+	}
+
+	if failed {
+		return 1
+	}
+	return 0
+}
+
+// printProcActionResults prints one line per targeted process
+func printProcActionResults(results []models.ProcessActionResult) {
+	for _, result := range results {
+		switch {
+		case result.DryRun:
+			fmt.Printf("%s %d (%s): would %s\n", color.CyanString("[dry-run]"), result.PID, result.Name, result.Action)
+		case result.Success:
+			detail := result.Action
+			if result.Detail != "" {
+				detail = result.Detail
+			}
+			fmt.Printf("%s %d (%s): %s\n", color.GreenString("[ok]"), result.PID, result.Name, detail)
+		default:
+			fmt.Printf("%s %d (%s): %s\n", color.RedString("[failed]"), result.PID, result.Name, result.Error)
+		}
+	}
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		fmt.Println()
+		return false
+	}
+
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
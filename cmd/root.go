@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,16 +18,49 @@ import (
 func Execute() {
 	cfg := config.NewConfig()
 
+	// Process actions are a separate command with their own flags
+	if len(os.Args) > 1 && os.Args[1] == "proc" {
+		os.Exit(runProcCommand(os.Args[2:]))
+	}
+
 	cpuFlag := flag.Bool("cpu", false, "Display CPU information")
 	memFlag := flag.Bool("mem", false, "Display memory information")
 	diskFlag := flag.Bool("disk", false, "Display disk information")
+	diskIOFlag := flag.Bool("diskio", false, "Display disk I/O throughput and latency")
 	sysFlag := flag.Bool("sys", false, "Display detailed system information")
 	netFlag := flag.Bool("net", false, "Display network information")
 	netTrafficFlag := flag.Bool("nettraffic", false, "Display network traffic information")
 	procFlag := flag.Bool("proc", false, "Display process information")
+	procTreeFlag := flag.Bool("proc-tree", false, "Display processes as a parent/child tree")
+	collapseFlag := flag.String("collapse", "", "Comma-separated PIDs whose subtrees are collapsed in the process tree")
+	sortFlag := flag.String("sort", "cpu", "Sort processes by cpu, mem, pid, name, rss, start, threads or io")
+	ascFlag := flag.Bool("asc", false, "Sort processes in ascending order")
+	filterFlag := flag.String("filter", "", "Only show processes whose name or command line matches this regex")
+	userFlag := flag.String("user", "", "Only show processes owned by this user")
+	topFlag := flag.Int("top", 10, "Number of processes to show (0 for all)")
+	procHealthFlag := flag.Bool("proc-health", false, "Detect zombie, stuck (D state) and file descriptor exhausting processes")
+	stuckAfterFlag := flag.Duration("stuck-after", collectors.DefaultStuckAfter, "Time in D state after which a process counts as stuck")
+	groupByFlag := flag.String("group-by", "", "Aggregate process usage by user, exe or cgroup")
+	pidFlag := flag.Int("pid", 0, "Display details for a single process")
+	envFlag := flag.Bool("env", false, "Include the process environment in the -pid view")
 	dockerFlag := flag.Bool("docker", false, "Display Docker container information")
-	dockerLogsFlag := flag.String("container-logs", "", "Display logs for a Docker container (provide container ID or name)")
+	dockerAllFlag := flag.Bool("docker-all", false, "Display all Docker containers, including stopped ones")
+	dockerProjectFlag := flag.String("docker-project", "", "Only display containers of this Docker Compose project")
+	runtimeFlag := flag.String("runtime", collectors.RuntimeAuto, "Container runtime to read containers from: auto, docker, podman, containerd or cgroup")
+	dockerDiskFlag := flag.Bool("docker-df", false, "Display disk space used by Docker images, containers, volumes and build cache")
+	containerHealthFlag := flag.Bool("container-health", false, "Detect unhealthy and crash-looping containers")
+	restartLoopFlag := flag.Int("restart-loop", collectors.DefaultRestartLoop, "Number of container restarts within -restart-window that counts as a restart loop")
+	restartWindowFlag := flag.Duration("restart-window", collectors.DefaultRestartWindow, "Window container restarts are counted in")
+	dockerEventsFlag := flag.Bool("docker-events", false, "Stream Docker container, image and network events")
+	eventFilterFlag := flag.String("event-filter", "", "Comma-separated KEY=VALUE Docker event filters, e.g. type=container,event=die,container=web")
+	dockerLogsFlag := flag.String("container-logs", "", "Display logs for Docker containers: comma-separated names or IDs, label=KEY[=VALUE] or project=NAME")
 	logsLimitFlag := flag.Int("logs-limit", 50, "Limit the number of log lines to display")
+	followFlag := flag.Bool("follow", false, "Stream new container log lines as they are written")
+	sinceFlag := flag.String("since", "", "Only show container logs or events since a time (RFC 3339, date, Unix time) or duration ago (10m)")
+	untilFlag := flag.String("until", "", "Only show container logs or events until a time (RFC 3339, date, Unix time) or duration ago (10m)")
+	includeFlag := flag.String("include", "", "Only show container log lines matching this regex")
+	excludeFlag := flag.String("exclude", "", "Hide container log lines matching this regex")
+	ndjsonFlag := flag.Bool("ndjson", false, "Write container logs as one JSON object per line (implies -json)")
 	batteryFlag := flag.Bool("battery", false, "Display battery information")
 	tempFlag := flag.Bool("temp", false, "Display temperature information")
 	logsFlag := flag.Bool("logs", false, "Display system logs")
@@ -51,17 +85,100 @@ func Execute() {
 		os.Exit(0)
 	}
 
-	if *dockerLogsFlag != "" {
+	// The tree and grouped views are different renderings of the process section
+	if *procTreeFlag || *groupByFlag != "" {
+		*procFlag = true
+	}
+
+	if *dockerAllFlag || *dockerProjectFlag != "" {
+		*dockerFlag = true
+	}
+
+	if err := collectors.SetContainerRuntime(*runtimeFlag); err != nil {
+		fmt.Printf("Error: invalid -runtime value: %v\n", err)
+		os.Exit(1)
+	}
+
+	collapsePIDs, err := parsePIDList(*collapseFlag)
+	if err != nil {
+		fmt.Printf("Error: invalid -collapse value: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *pidFlag > 0 {
 		opts := models.Options{
 			JSONOutput:    *jsonFlag,
 			VerboseOutput: *verboseFlag,
 		}
+		collectors.GetProcessDetailInfo(*pidFlag, *envFlag, opts)
+		return
+	}
+
+	if *dockerLogsFlag != "" {
+		since, err := collectors.ParseLogTime(*sinceFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid -since value: %v\n", err)
+			os.Exit(1)
+		}
+		until, err := collectors.ParseLogTime(*untilFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid -until value: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := models.Options{
+			JSONOutput:    *jsonFlag || *ndjsonFlag,
+			VerboseOutput: *verboseFlag,
+			Logs: models.LogDisplay{
+				Follow:  *followFlag,
+				Since:   since,
+				Until:   until,
+				Include: *includeFlag,
+				Exclude: *excludeFlag,
+				NDJSON:  *ndjsonFlag,
+			},
+		}
+
+		if err := collectors.ValidateLogDisplay(opts.Logs); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
 		collectors.GetContainerLogs(*dockerLogsFlag, *logsLimitFlag, opts)
 		return
 	}
 
-	if !(*cpuFlag || *memFlag || *diskFlag || *sysFlag || *netFlag || *netTrafficFlag || *procFlag || 
-	     *dockerFlag || *batteryFlag || *tempFlag || *logsFlag || *historyFlag || *alertsFlag || *allFlag) {
+	if *dockerEventsFlag {
+		since, err := collectors.ParseLogTime(*sinceFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid -since value: %v\n", err)
+			os.Exit(1)
+		}
+		until, err := collectors.ParseLogTime(*untilFlag)
+		if err != nil {
+			fmt.Printf("Error: invalid -until value: %v\n", err)
+			os.Exit(1)
+		}
+		filters, err := collectors.ParseEventFilters(*eventFilterFlag)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		opts := models.Options{
+			JSONOutput: *jsonFlag || *ndjsonFlag,
+			Events: models.EventDisplay{
+				Since:   since,
+				Until:   until,
+				Filters: filters,
+			},
+		}
+		collectors.GetDockerEvents(opts)
+		return
+	}
+
+	if !(*cpuFlag || *memFlag || *diskFlag || *diskIOFlag || *sysFlag || *netFlag || *netTrafficFlag || *procFlag || *procHealthFlag || 
+	     *dockerFlag || *dockerDiskFlag || *containerHealthFlag || *batteryFlag || *tempFlag || *logsFlag || *historyFlag || *alertsFlag || *allFlag) {
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -71,6 +188,28 @@ func Execute() {
 		InWatchMode:   *watchFlag,
 		VerboseOutput: *verboseFlag,
 		EnableAlerts:  *alertsFlag,
+		Process: models.ProcessDisplay{
+			SortBy:     *sortFlag,
+			Ascending:  *ascFlag,
+			Filter:     *filterFlag,
+			Limit:      *topFlag,
+			User:       *userFlag,
+			Tree:       *procTreeFlag,
+			Collapse:   collapsePIDs,
+			GroupBy:    *groupByFlag,
+			StuckAfter: *stuckAfterFlag,
+		},
+		Docker: models.DockerDisplay{
+			All:           *dockerAllFlag,
+			Project:       *dockerProjectFlag,
+			RestartLoop:   *restartLoopFlag,
+			RestartWindow: *restartWindowFlag,
+		},
+	}
+
+	if err := collectors.ValidateProcessDisplay(opts.Process); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
 
 	if *watchFlag && *jsonFlag {
@@ -89,8 +228,8 @@ func Execute() {
             // Remove any other title that might be displayed after the banner
         }
         
-        displayInfo(opts, *cpuFlag, *memFlag, *diskFlag, *sysFlag, *netFlag, *netTrafficFlag, *procFlag, 
-                   *dockerFlag, *batteryFlag, *tempFlag, *logsFlag, *historyFlag, *alertsFlag, *allFlag, *jsonFlag, cfg)
+        displayInfo(opts, *cpuFlag, *memFlag, *diskFlag, *diskIOFlag, *sysFlag, *netFlag, *netTrafficFlag, *procFlag, *procHealthFlag, 
+                   *dockerFlag, *dockerDiskFlag, *containerHealthFlag, *batteryFlag, *tempFlag, *logsFlag, *historyFlag, *alertsFlag, *allFlag, *jsonFlag, cfg)
         
         if !*jsonFlag {
 			fmt.Println()
@@ -99,12 +238,12 @@ func Execute() {
 		}
 		return
 	} else {
-		runWatchMode(opts, *cpuFlag, *memFlag, *diskFlag, *sysFlag, *netFlag, *netTrafficFlag, *procFlag, 
-		            *dockerFlag, *batteryFlag, *tempFlag, *logsFlag, *historyFlag, *alertsFlag, *allFlag, refreshRate)
+		runWatchMode(opts, *cpuFlag, *memFlag, *diskFlag, *diskIOFlag, *sysFlag, *netFlag, *netTrafficFlag, *procFlag, *procHealthFlag, 
+		            *dockerFlag, *dockerDiskFlag, *containerHealthFlag, *batteryFlag, *tempFlag, *logsFlag, *historyFlag, *alertsFlag, *allFlag, refreshRate)
 	}
 }
 
-func displayInfo(opts models.Options, cpu, mem, disk, sys, net, netTraffic, proc, docker, battery, temp, logs, history, alerts, all, json bool, cfg *config.Config) {
+func displayInfo(opts models.Options, cpu, mem, disk, diskIO, sys, net, netTraffic, proc, procHealth, docker, dockerDisk, containerHealth, battery, temp, logs, history, alerts, all, json bool, cfg *config.Config) {
     if json {
         if sys || all {
             collectors.GetSystemInfo(opts)
@@ -122,6 +261,10 @@ func displayInfo(opts models.Options, cpu, mem, disk, sys, net, netTraffic, proc
             collectors.GetDiskInfo(opts)
         }
         
+        if diskIO || all {
+            collectors.GetDiskIOInfo(opts)
+        }
+        
         if net || all {
             collectors.GetNetworkInfo(opts)
         }
@@ -134,10 +277,23 @@ func displayInfo(opts models.Options, cpu, mem, disk, sys, net, netTraffic, proc
             collectors.GetProcessInfo(opts)
         }
         
+        if procHealth || all {
+            collectors.GetProcessHealthInfo(opts)
+        }
+        
         if docker || all {
             collectors.GetDockerInfo(opts)
         }
         
+        // Sizing every layer and volume is slow, so -all leaves it out
+        if dockerDisk {
+            collectors.GetDockerDiskUsageInfo(opts)
+        }
+        
+        if containerHealth || all {
+            collectors.GetContainerHealthInfo(opts)
+        }
+        
         if battery || all {
             collectors.GetBatteryInfo(opts)
         }
@@ -161,7 +317,7 @@ func displayInfo(opts models.Options, cpu, mem, disk, sys, net, netTraffic, proc
         return
     }
     
-    allSections := collectDisplaySections(opts, cpu, mem, disk, sys, net, netTraffic, proc, docker, battery, temp, logs, history, alerts, all)
+    allSections := collectDisplaySections(opts, cpu, mem, disk, diskIO, sys, net, netTraffic, proc, procHealth, docker, dockerDisk, containerHealth, battery, temp, logs, history, alerts, all)
     
     ui.CompactDisplay(allSections)
     
@@ -173,7 +329,7 @@ func displayInfo(opts models.Options, cpu, mem, disk, sys, net, netTraffic, proc
     }
 }
 
-func runWatchMode(opts models.Options, cpuFlag, memFlag, diskFlag, sysFlag, netFlag, netTrafficFlag, procFlag, dockerFlag, batteryFlag, tempFlag, logsFlag, historyFlag, alertsFlag, allFlag bool, refreshRate int) {
+func runWatchMode(opts models.Options, cpuFlag, memFlag, diskFlag, diskIOFlag, sysFlag, netFlag, netTrafficFlag, procFlag, procHealthFlag, dockerFlag, dockerDiskFlag, containerHealthFlag, batteryFlag, tempFlag, logsFlag, historyFlag, alertsFlag, allFlag bool, refreshRate int) {
     for {
         ui.ClearScreen()
         
@@ -194,7 +350,7 @@ func runWatchMode(opts models.Options, cpuFlag, memFlag, diskFlag, sysFlag, netF
         watchOpts := opts
         watchOpts.CompactMode = true
         
-        sections := collectDisplaySections(watchOpts, cpuFlag, memFlag, diskFlag, sysFlag, netFlag, netTrafficFlag, procFlag, dockerFlag, batteryFlag, tempFlag, logsFlag, historyFlag, alertsFlag, allFlag)
+        sections := collectDisplaySections(watchOpts, cpuFlag, memFlag, diskFlag, diskIOFlag, sysFlag, netFlag, netTrafficFlag, procFlag, procHealthFlag, dockerFlag, dockerDiskFlag, containerHealthFlag, batteryFlag, tempFlag, logsFlag, historyFlag, alertsFlag, allFlag)
         
         ui.CompactDisplay(sections)
         
@@ -202,7 +358,7 @@ func runWatchMode(opts models.Options, cpuFlag, memFlag, diskFlag, sysFlag, netF
     }
 }
 
-func collectDisplaySections(opts models.Options, cpu, mem, disk, sys, net, netTraffic, proc, docker, battery, temp, logs, history, alerts, all bool) map[string][][]string {
+func collectDisplaySections(opts models.Options, cpu, mem, disk, diskIO, sys, net, netTraffic, proc, procHealth, docker, dockerDisk, containerHealth, battery, temp, logs, history, alerts, all bool) map[string][][]string {
     allSections := make(map[string][][]string)
     
     if sys || all {
@@ -233,6 +389,13 @@ func collectDisplaySections(opts models.Options, cpu, mem, disk, sys, net, netTr
         }
     }
     
+    if diskIO || all {
+        diskIOSections := collectors.GetDiskIOInfoSections(opts)
+        for k, v := range diskIOSections {
+            allSections[k] = v
+        }
+    }
+    
     if net || all {
         networkSections := collectors.GetNetworkInfoSections(opts)
         for k, v := range networkSections {
@@ -248,25 +411,79 @@ func collectDisplaySections(opts models.Options, cpu, mem, disk, sys, net, netTr
     }
     
     if proc || all {
-        processes, err := collectors.GetTopProcesses(10)
+        if opts.Process.Tree {
+            processes, err := collectors.GetTopProcesses(0)
+            if err == nil {
+                treeSections := collectors.GetProcessTreeSections(processes, opts)
+                for k, v := range treeSections {
+                    allSections[k] = v
+                }
+            }
+        } else if opts.Process.GroupBy != "" {
+            processes, err := collectors.GetTopProcesses(0)
+            if err == nil {
+                groupSections := collectors.GetProcessGroupSections(processes, opts)
+                for k, v := range groupSections {
+                    allSections[k] = v
+                }
+            }
+        } else {
+            allProcesses, err := collectors.GetTopProcesses(0)
+            if err == nil {
+                processes := collectors.ApplyProcessDisplay(allProcesses, opts.Process)
+                processSections := collectors.GetProcessInfoSections(processes, opts)
+                for k, v := range processSections {
+                    allSections[k] = v
+                }
+                
+                ioSections := collectors.GetProcessIOSections(allProcesses, opts)
+                for k, v := range ioSections {
+                    allSections[k] = v
+                }
+            }
+        }
+    }
+    
+    if procHealth || all {
+        health, err := collectors.CheckProcessHealth(opts)
         if err == nil {
-            processSections := collectors.GetProcessInfoSections(processes, opts)
-            for k, v := range processSections {
+            healthSections := collectors.GetProcessHealthSections(health, opts)
+            for k, v := range healthSections {
                 allSections[k] = v
             }
         }
     }
     
     if docker || all {
-        containers, err := collectors.GetDockerContainers()
+        containers, projects, err := collectors.GetComposeProjects(opts.Docker)
         if err == nil {
-            dockerSections := collectors.GetDockerInfoSections(containers, opts)
+            dockerSections := collectors.GetDockerInfoSections(containers, projects, opts)
             for k, v := range dockerSections {
                 allSections[k] = v
             }
         }
     }
     
+    if dockerDisk {
+        usage, err := collectors.GetDockerDiskUsage()
+        if err == nil {
+            diskUsageSections := collectors.GetDockerDiskUsageSections(usage, opts)
+            for k, v := range diskUsageSections {
+                allSections[k] = v
+            }
+        }
+    }
+    
+    if containerHealth || all {
+        health, err := collectors.CheckContainerHealth(opts)
+        if err == nil {
+            healthSections := collectors.GetContainerHealthSections(health, opts)
+            for k, v := range healthSections {
+                allSections[k] = v
+            }
+        }
+    }
+    
     if battery || all {
         batterySections := collectors.GetBatteryInfoSections(opts)
         for k, v := range batterySections {
@@ -331,3 +548,21 @@ func getResourceHistorySections(opts models.Options) map[string][][]string {
     
     return result
 }
+
+// parsePIDList parses a comma-separated list of process IDs
+func parsePIDList(value string) ([]int, error) {
+    pids := []int{}
+    if strings.TrimSpace(value) == "" {
+        return pids, nil
+    }
+    
+    for _, part := range strings.Split(value, ",") {
+        pid, err := strconv.Atoi(strings.TrimSpace(part))
+        if err != nil || pid <= 0 {
+            return nil, fmt.Errorf("'%s' is not a valid PID", part)
+        }
+        pids = append(pids, pid)
+    }
+    
+    return pids, nil
+}
@@ -39,6 +39,8 @@ type ThresholdConfig struct {
 	MemoryCritical float64
 	DiskWarning    float64
 	DiskCritical   float64
+	FDWarning      float64
+	FDCritical     float64
 }
 
 // DefaultThresholds returns sensible default threshold values
@@ -50,6 +52,8 @@ func DefaultThresholds() ThresholdConfig {
 		MemoryCritical: 95.0,
 		DiskWarning:    85.0,
 		DiskCritical:   95.0,
+		FDWarning:      80.0,
+		FDCritical:     95.0,
 	}
 }
 
@@ -169,6 +173,109 @@ func (am *AlertManager) CheckDisk(usage float64, path string) {
 	}
 }
 
+// CheckZombie creates an alert for a zombie process that its parent hasn't reaped
+func (am *AlertManager) CheckZombie(pid int, name string, parentPID int, parentName string) {
+	am.AddAlert(
+		Warning,
+		"Zombie Process",
+		fmt.Sprintf("Process %d (%s) is a zombie, its parent %d (%s) has not reaped it", pid, name, parentPID, parentName),
+		"Process",
+		float64(pid),
+		0,
+	)
+}
+
+// CheckStuckProcess creates an alert for a process in uninterruptible sleep for longer than the threshold
+func (am *AlertManager) CheckStuckProcess(pid int, name string, stuck, threshold time.Duration) {
+	if stuck < threshold {
+		return
+	}
+
+	am.AddAlert(
+		Warning,
+		"Process Stuck in I/O",
+		fmt.Sprintf("Process %d (%s) has been in uninterruptible sleep (D state) for %s", pid, name, stuck.Round(time.Second)),
+		"Process",
+		stuck.Seconds(),
+		threshold.Seconds(),
+	)
+}
+
+// CheckFileDescriptors creates alerts for processes close to their open file limit
+func (am *AlertManager) CheckFileDescriptors(pid int, name string, open int, limit uint64) {
+	if limit == 0 {
+		return
+	}
+
+	usage := float64(open) / float64(limit) * 100
+	if usage >= am.Thresholds.FDCritical {
+		am.AddAlert(
+			Critical,
+			"Critical File Descriptor Usage",
+			fmt.Sprintf("Process %d (%s) has %d of %d file descriptors open (%.1f%%), exceeding the critical threshold of %.1f%%", pid, name, open, limit, usage, am.Thresholds.FDCritical),
+			"Process",
+			usage,
+			am.Thresholds.FDCritical,
+		)
+	} else if usage >= am.Thresholds.FDWarning {
+		am.AddAlert(
+			Warning,
+			"High File Descriptor Usage",
+			fmt.Sprintf("Process %d (%s) has %d of %d file descriptors open (%.1f%%), exceeding the warning threshold of %.1f%%", pid, name, open, limit, usage, am.Thresholds.FDWarning),
+			"Process",
+			usage,
+			am.Thresholds.FDWarning,
+		)
+	}
+}
+
+// CheckRestartLoop creates an alert for a container restarting repeatedly within the window.
+// A zero window means the restarts were counted over the container's lifetime.
+func (am *AlertManager) CheckRestartLoop(name string, restarts, threshold int, window time.Duration) {
+	if restarts < threshold {
+		return
+	}
+
+	message := fmt.Sprintf("Container %s restarted %d times within %s", name, restarts, window)
+	if window == 0 {
+		message = fmt.Sprintf("Container %s keeps restarting, %d restarts so far", name, restarts)
+	}
+
+	am.AddAlert(
+		Critical,
+		"Container Restart Loop",
+		message,
+		"Container",
+		float64(restarts),
+		float64(threshold),
+	)
+}
+
+// CheckContainerHealth creates alerts for a container whose health check fails, and
+// notes when it recovers
+func (am *AlertManager) CheckContainerHealth(name, previous, current, output string) {
+	switch {
+	case current == "unhealthy":
+		message := fmt.Sprintf("Container %s is unhealthy", name)
+		if previous != "" && previous != current {
+			message = fmt.Sprintf("Container %s changed from %s to unhealthy", name, previous)
+		}
+		if output != "" {
+			message += ": " + output
+		}
+		am.AddAlert(Warning, "Container Unhealthy", message, "Container", 0, 0)
+	case current == "healthy" && previous == "unhealthy":
+		am.AddAlert(
+			Info,
+			"Container Recovered",
+			fmt.Sprintf("Container %s is healthy again", name),
+			"Container",
+			0,
+			0,
+		)
+	}
+}
+
 // GetAlertsByLevel returns alerts filtered by level
 func (am *AlertManager) GetAlertsByLevel(level AlertLevel) []Alert {
 	filtered := make([]Alert, 0)
@@ -49,6 +49,8 @@ func GetAlertsInfoSections(opts models.Options) map[string][][]string {
 		{"Memory Critical", fmt.Sprintf("%.1f%%", thresholds.MemoryCritical)},
 		{"Disk Warning", fmt.Sprintf("%.1f%%", thresholds.DiskWarning)},
 		{"Disk Critical", fmt.Sprintf("%.1f%%", thresholds.DiskCritical)},
+		{"FD Warning", fmt.Sprintf("%.1f%%", thresholds.FDWarning)},
+		{"FD Critical", fmt.Sprintf("%.1f%%", thresholds.FDCritical)},
 	}
 	
 	// Create a section for recent alerts
@@ -130,14 +132,15 @@ func ConfigureAlertThresholds(cpuWarn, cpuCrit, memWarn, memCrit, diskWarn, disk
 	}
 	
 	// Update thresholds
-	alertManager.Thresholds = alerts.ThresholdConfig{
-		CPUWarning:     cpuWarn,
-		CPUCritical:    cpuCrit,
-		MemoryWarning:  memWarn,
-		MemoryCritical: memCrit,
-		DiskWarning:    diskWarn,
-		DiskCritical:   diskCrit,
-	}
+	// Thresholds not configured here, like file descriptors, keep their values
+	thresholds := alertManager.Thresholds
+	thresholds.CPUWarning = cpuWarn
+	thresholds.CPUCritical = cpuCrit
+	thresholds.MemoryWarning = memWarn
+	thresholds.MemoryCritical = memCrit
+	thresholds.DiskWarning = diskWarn
+	thresholds.DiskCritical = diskCrit
+	alertManager.Thresholds = thresholds
 	
 	// Add an informational alert about the update
 	alertManager.AddAlert(
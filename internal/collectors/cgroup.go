@@ -0,0 +1,389 @@
+package collectors
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// Mount points of the cgroup v2 hierarchy: unified mode, then hybrid mode
+var cgroupMountPoints = []string{"/sys/fs/cgroup", "/sys/fs/cgroup/unified"}
+
+// Interval used for the second reading when no previous cgroup sample exists
+const cgroupSampleInterval = 250 * time.Millisecond
+
+// Container cgroup directory names. The systemd cgroup driver creates scopes named after
+// the engine (docker-, libpod-, cri-containerd-, crio-), the cgroupfs driver uses the bare
+// ID. Conmon scopes hold Podman's and CRI-O's monitor process, not the container.
+var (
+	containerScopePattern = regexp.MustCompile(`^(docker|libpod|cri-containerd|crio)-([0-9a-f]{64})\.scope$`)
+	containerDirPattern   = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	podSlicePattern       = regexp.MustCompile(`^kubepods(?:-[a-z]+)*-pod([0-9a-f_]{36})\.slice$`)
+	podDirPattern         = regexp.MustCompile(`^pod([0-9a-f-]{36})$`)
+)
+
+// Engines named in container scopes
+var cgroupScopeRuntimes = map[string]string{
+	"docker":         RuntimeDocker,
+	"libpod":         RuntimePodman,
+	"cri-containerd": RuntimeContainerd,
+	"crio":           "cri-o",
+}
+
+// containerCgroup is a cgroup that holds a container
+type containerCgroup struct {
+	ID      string // Full container ID
+	Runtime string // Engine that created it, when the directory name tells
+	PodUID  string // Kubernetes pod the container belongs to
+	Path    string // Path below the cgroup mount point
+}
+
+// cgroupCPUSample holds the cumulative CPU counters of a cgroup
+type cgroupCPUSample struct {
+	usageUsec        uint64
+	periods          uint64
+	throttledPeriods uint64
+}
+
+// Cgroup CPU sample store, kept across watch mode ticks
+var (
+	lastCgroupSamples map[string]cgroupCPUSample
+	lastCgroupReadAt  time.Time
+	cgroupMu          sync.Mutex
+)
+
+// cgroupV2Root returns the mount point of the cgroup v2 hierarchy
+func cgroupV2Root() (string, error) {
+	for _, root := range cgroupMountPoints {
+		if _, err := os.Stat(filepath.Join(root, "cgroup.controllers")); err == nil {
+			return root, nil
+		}
+	}
+	return "", fmt.Errorf("cgroup v2 is not mounted")
+}
+
+// findContainerCgroups walks the cgroup v2 hierarchy for container cgroups, sorted by path
+func findContainerCgroups() ([]containerCgroup, error) {
+	root, err := cgroupV2Root()
+	if err != nil {
+		return nil, err
+	}
+
+	cgroups := []containerCgroup{}
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// Cgroups disappear while being walked
+			if path != root {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() || path == root {
+			return nil
+		}
+
+		name := entry.Name()
+		cgroup := containerCgroup{}
+		if match := containerScopePattern.FindStringSubmatch(name); match != nil {
+			cgroup.ID = match[2]
+			cgroup.Runtime = cgroupScopeRuntimes[match[1]]
+		} else if containerDirPattern.MatchString(name) {
+			cgroup.ID = name
+			if filepath.Base(filepath.Dir(path)) == "docker" {
+				cgroup.Runtime = RuntimeDocker
+			}
+		} else {
+			return nil
+		}
+
+		cgroup.Path, _ = filepath.Rel(root, path)
+		cgroup.Path = "/" + filepath.ToSlash(cgroup.Path)
+		cgroup.PodUID = cgroupPodUID(cgroup.Path)
+		cgroups = append(cgroups, cgroup)
+
+		// Cgroups below a container are the container's own
+		return filepath.SkipDir
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(cgroups, func(i, j int) bool {
+		return cgroups[i].Path < cgroups[j].Path
+	})
+	return cgroups, nil
+}
+
+// cgroupPodUID finds the Kubernetes pod UID in a cgroup path, as named by the systemd
+// driver (kubepods-burstable-pod<uid with underscores>.slice) or cgroupfs (pod<uid>)
+func cgroupPodUID(path string) string {
+	for _, part := range strings.Split(path, "/") {
+		if match := podSlicePattern.FindStringSubmatch(part); match != nil {
+			return strings.ReplaceAll(match[1], "_", "-")
+		}
+		if match := podDirPattern.FindStringSubmatch(part); match != nil {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// sampleContainerCgroups reads the stats of container cgroups keyed by short container
+// ID, with CPU usage and throttling since the previous reading
+func sampleContainerCgroups(cgroups []containerCgroup) map[string]models.CgroupStats {
+	cgroupMu.Lock()
+	defer cgroupMu.Unlock()
+
+	root, err := cgroupV2Root()
+	if err != nil {
+		return map[string]models.CgroupStats{}
+	}
+
+	read := func() map[string]models.CgroupStats {
+		stats := make(map[string]models.CgroupStats, len(cgroups))
+		for _, cgroup := range cgroups {
+			if cgroupStats, err := readCgroupStats(root, cgroup.Path); err == nil {
+				stats[shortContainerID(cgroup.ID)] = cgroupStats
+			}
+		}
+		return stats
+	}
+
+	current := read()
+
+	// Without a previous reading, take a second one after a short interval
+	if lastCgroupSamples == nil {
+		lastCgroupSamples = cgroupCPUSamples(current)
+		lastCgroupReadAt = time.Now()
+		time.Sleep(cgroupSampleInterval)
+		current = read()
+	}
+
+	now := time.Now()
+	elapsedUsec := float64(now.Sub(lastCgroupReadAt).Microseconds())
+	if elapsedUsec < 1 {
+		elapsedUsec = 1
+	}
+
+	for id, stats := range current {
+		prev, ok := lastCgroupSamples[stats.Path]
+		if !ok {
+			continue
+		}
+
+		// Relative to a single core, like `docker stats`
+		stats.CPUPercent = float64(counterDelta(prev.usageUsec, stats.CPUUsageUsec)) / elapsedUsec * 100
+		if periods := counterDelta(prev.periods, stats.Periods); periods > 0 {
+			stats.ThrottledPercent = float64(counterDelta(prev.throttledPeriods, stats.ThrottledPeriods)) / float64(periods) * 100
+		}
+		current[id] = stats
+	}
+
+	lastCgroupSamples = cgroupCPUSamples(current)
+	lastCgroupReadAt = now
+	return current
+}
+
+// cgroupCPUSamples keeps the CPU counters of a reading, keyed by cgroup path
+func cgroupCPUSamples(stats map[string]models.CgroupStats) map[string]cgroupCPUSample {
+	samples := make(map[string]cgroupCPUSample, len(stats))
+	for _, cgroupStats := range stats {
+		samples[cgroupStats.Path] = cgroupCPUSample{
+			usageUsec:        cgroupStats.CPUUsageUsec,
+			periods:          cgroupStats.Periods,
+			throttledPeriods: cgroupStats.ThrottledPeriods,
+		}
+	}
+	return samples
+}
+
+// readCgroupStats reads the cumulative counters and limits of a cgroup. Files of
+// controllers that aren't enabled for the cgroup are missing and leave their fields zero.
+func readCgroupStats(root, path string) (models.CgroupStats, error) {
+	dir := filepath.Join(root, filepath.FromSlash(path))
+	cpu, err := readCgroupKeyValues(filepath.Join(dir, "cpu.stat"))
+	if err != nil {
+		return models.CgroupStats{}, err
+	}
+
+	stats := models.CgroupStats{
+		Path:             path,
+		CPUUsageUsec:     cpu["usage_usec"],
+		CPULimit:         readCgroupCPULimit(dir),
+		Periods:          cpu["nr_periods"],
+		ThrottledPeriods: cpu["nr_throttled"],
+		ThrottledUsec:    cpu["throttled_usec"],
+	}
+
+	stats.MemoryCurrent, _ = readCgroupValue(filepath.Join(dir, "memory.current"))
+	stats.MemoryMax, _ = readCgroupValue(filepath.Join(dir, "memory.max"))
+	if events, err := readCgroupKeyValues(filepath.Join(dir, "memory.events")); err == nil {
+		stats.OOMEvents = events["oom"]
+		stats.OOMKills = events["oom_kill"]
+	}
+
+	stats.IOReadBytes, stats.IOWriteBytes = readCgroupIOStat(filepath.Join(dir, "io.stat"))
+	stats.PIDs, _ = readCgroupValue(filepath.Join(dir, "pids.current"))
+	stats.PIDsMax, _ = readCgroupValue(filepath.Join(dir, "pids.max"))
+
+	return stats, nil
+}
+
+// readCgroupValue reads a single-value cgroup file. "max" means no limit and reads as 0.
+func readCgroupValue(path string) (uint64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	value := strings.TrimSpace(string(data))
+	if value == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// readCgroupKeyValues reads a flat keyed cgroup file such as cpu.stat or memory.events
+func readCgroupKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if value, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = value
+		}
+	}
+	return values, scanner.Err()
+}
+
+// readCgroupCPULimit returns the CPU limit of a cgroup in cores from cpu.max
+// ("$QUOTA $PERIOD"), or 0 when the quota is "max"
+func readCgroupCPULimit(dir string) float64 {
+	data, err := os.ReadFile(filepath.Join(dir, "cpu.max"))
+	if err != nil {
+		return 0
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0
+	}
+	quota, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0
+	}
+	period, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || period == 0 {
+		return 0
+	}
+	return quota / period
+}
+
+// readCgroupIOStat sums bytes read and written over all devices in io.stat, where each
+// line is "MAJ:MIN rbytes=N wbytes=N rios=N wios=N dbytes=N dios=N"
+func readCgroupIOStat(path string) (uint64, uint64) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0
+	}
+
+	var read, written uint64
+	for _, line := range strings.Split(string(data), "\n") {
+		for _, field := range strings.Fields(line) {
+			key, value, found := strings.Cut(field, "=")
+			if !found {
+				continue
+			}
+			count, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				read += count
+			case "wbytes":
+				written += count
+			}
+		}
+	}
+	return read, written
+}
+
+// readCgroupMemoryUsage returns memory.current without inactive page cache, which can be
+// reclaimed, like `docker stats`
+func readCgroupMemoryUsage(root string, stats models.CgroupStats) uint64 {
+	memoryStat, err := readCgroupKeyValues(filepath.Join(root, filepath.FromSlash(stats.Path), "memory.stat"))
+	if err != nil {
+		return stats.MemoryCurrent
+	}
+	if cache := memoryStat["inactive_file"]; cache < stats.MemoryCurrent {
+		return stats.MemoryCurrent - cache
+	}
+	return stats.MemoryCurrent
+}
+
+// attachCgroupStats adds the cgroup stats and usage of containers running on this host,
+// matched by ID. Containers of a remote engine or hosts without cgroup v2 are left as they are.
+func attachCgroupStats(containers []models.ContainerInfo) {
+	running := false
+	for _, container := range containers {
+		if container.State == "running" {
+			running = true
+			break
+		}
+	}
+	if !running {
+		return
+	}
+
+	cgroups, err := findContainerCgroups()
+	if err != nil || len(cgroups) == 0 {
+		return
+	}
+
+	stats := sampleContainerCgroups(cgroups)
+	root, _ := cgroupV2Root()
+	pods := make(map[string]string, len(cgroups))
+	for _, cgroup := range cgroups {
+		pods[shortContainerID(cgroup.ID)] = cgroup.PodUID
+	}
+
+	for i := range containers {
+		if cgroupStats, ok := stats[containers[i].ID]; ok {
+			applyCgroupStats(&containers[i], root, cgroupStats)
+			if containers[i].PodUID == "" {
+				containers[i].PodUID = pods[containers[i].ID]
+			}
+		}
+	}
+}
+
+// applyCgroupStats sets the cgroup stats of a container and its CPU and memory usage from them
+func applyCgroupStats(container *models.ContainerInfo, root string, stats models.CgroupStats) {
+	container.Cgroup = &stats
+	container.CPUPercent = stats.CPUPercent
+	container.MemoryUsage = readCgroupMemoryUsage(root, stats)
+	container.MemoryLimit = stats.MemoryMax
+	if stats.MemoryMax > 0 {
+		container.MemoryPerc = float64(container.MemoryUsage) / float64(stats.MemoryMax) * 100
+	}
+}
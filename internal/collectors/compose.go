@@ -0,0 +1,206 @@
+package collectors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Labels Docker Compose puts on the containers it creates
+const (
+	composeProjectLabel = "com.docker.compose.project"
+	composeServiceLabel = "com.docker.compose.service"
+	composeOneOffLabel  = "com.docker.compose.oneoff"
+)
+
+// GetComposeProjects returns the containers to show and the Compose projects they
+// belong to, scoped to display.Project when set
+func GetComposeProjects(display models.DockerDisplay) ([]models.ContainerInfo, []models.ComposeProject, error) {
+	containers, desired, err := collectRuntimeContainers(display, true)
+	if err != nil {
+		return containers, nil, err
+	}
+
+	return containers, GroupComposeProjects(containers, desired), nil
+}
+
+// GroupComposeProjects groups containers by their Compose project and service and sums
+// their usage. desired holds the number of containers Compose created per service, keyed
+// by composeServiceKey; stopped ones count as desired but not running.
+func GroupComposeProjects(containers []models.ContainerInfo, desired map[string]int) []models.ComposeProject {
+	projects := make(map[string]*models.ComposeProject)
+	services := make(map[string]*models.ComposeService)
+	projectServices := make(map[string][]string)
+
+	for _, container := range containers {
+		if container.Project == "" {
+			continue
+		}
+
+		project, ok := projects[container.Project]
+		if !ok {
+			project = &models.ComposeProject{Name: container.Project}
+			projects[container.Project] = project
+		}
+		project.CPUPercent += container.CPUPercent
+		project.MemoryUsage += container.MemoryUsage
+
+		// One-off `compose run` containers count towards the project but not its services
+		if container.Service == "" {
+			continue
+		}
+
+		key := composeServiceKey(container.Project, container.Service)
+		service, ok := services[key]
+		if !ok {
+			service = &models.ComposeService{Name: container.Service, Desired: desired[key]}
+			services[key] = service
+			projectServices[container.Project] = append(projectServices[container.Project], key)
+		}
+
+		service.Containers = append(service.Containers, container.Name)
+		service.CPUPercent += container.CPUPercent
+		service.MemoryUsage += container.MemoryUsage
+		if container.State == "running" {
+			service.Running++
+			service.Health = worseHealth(service.Health, container.Health)
+		}
+	}
+
+	// Services whose containers are all stopped and not shown still have desired replicas
+	for key, count := range desired {
+		projectName, serviceName, _ := strings.Cut(key, "/")
+		project, ok := projects[projectName]
+		if !ok {
+			continue
+		}
+		if _, ok := services[key]; !ok {
+			services[key] = &models.ComposeService{Name: serviceName, Desired: count, Containers: []string{}}
+			projectServices[project.Name] = append(projectServices[project.Name], key)
+		}
+	}
+
+	result := make([]models.ComposeProject, 0, len(projects))
+	for _, project := range projects {
+		keys := projectServices[project.Name]
+		sort.Strings(keys)
+
+		project.Services = make([]models.ComposeService, 0, len(keys))
+		for _, key := range keys {
+			service := *services[key]
+			if service.Desired < service.Running {
+				service.Desired = service.Running
+			}
+			sort.Strings(service.Containers)
+
+			project.Running += service.Running
+			project.Desired += service.Desired
+			if service.Health == "unhealthy" || service.Running < service.Desired {
+				project.Unhealthy = append(project.Unhealthy, service.Name)
+			}
+			project.Services = append(project.Services, service)
+		}
+		result = append(result, *project)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// GetComposeProjectSections formats the projects as a table with one row per project
+// followed by a row per service
+func GetComposeProjectSections(projects []models.ComposeProject, opts models.Options) map[string][][]string {
+	if len(projects) == 0 {
+		return map[string][][]string{}
+	}
+
+	projectData := [][]string{
+		{"Project", "Service", "Up", "CPU%", "Memory", "Status"},
+	}
+
+	for _, project := range projects {
+		status := ui.SuccessColor("ok")
+		if len(project.Unhealthy) > 0 {
+			status = ui.DangerColor(fmt.Sprintf("%d unhealthy", len(project.Unhealthy)))
+		}
+
+		projectData = append(projectData, []string{
+			project.Name,
+			fmt.Sprintf("%d services", len(project.Services)),
+			replicaColor(project.Running, project.Desired),
+			ui.FormatPercent(project.CPUPercent),
+			formatBytes(project.MemoryUsage),
+			status,
+		})
+
+		for _, service := range project.Services {
+			status := "running"
+			switch {
+			case service.Running == 0:
+				status = ui.DangerColor("down")
+			case service.Health != "":
+				status = healthColor(service.Health)
+			}
+
+			projectData = append(projectData, []string{
+				"",
+				service.Name,
+				replicaColor(service.Running, service.Desired),
+				ui.FormatPercent(service.CPUPercent),
+				formatBytes(service.MemoryUsage),
+				status,
+			})
+		}
+	}
+
+	return map[string][][]string{
+		"Compose Projects": projectData,
+	}
+}
+
+// replicaColor formats running vs desired replicas, colored when some are missing
+func replicaColor(running, desired int) string {
+	replicas := fmt.Sprintf("%d/%d", running, desired)
+	switch {
+	case running == 0 && desired > 0:
+		return ui.DangerColor(replicas)
+	case running < desired:
+		return ui.WarningColor(replicas)
+	}
+	return replicas
+}
+
+// worseHealth returns the more severe of two health check statuses
+func worseHealth(a, b string) string {
+	rank := map[string]int{"": 0, "healthy": 1, "starting": 2, "unhealthy": 3}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// composeServiceKey identifies a service across projects
+func composeServiceKey(project, service string) string {
+	return project + "/" + service
+}
+
+// composeReplicas counts the containers Compose created per service, running or not,
+// which is the replica count the service was scaled to
+func composeReplicas(list []docker.Container) map[string]int {
+	desired := make(map[string]int)
+	for _, summary := range list {
+		project := summary.Labels[composeProjectLabel]
+		service := summary.Labels[composeServiceLabel]
+		if project == "" || service == "" || strings.EqualFold(summary.Labels[composeOneOffLabel], "true") {
+			continue
+		}
+		desired[composeServiceKey(project, service)]++
+	}
+	return desired
+}
@@ -0,0 +1,399 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Default number of restarts within DefaultRestartWindow that counts as a restart loop
+const DefaultRestartLoop = 3
+
+// Default window restarts are counted in
+const DefaultRestartWindow = 5 * time.Minute
+
+// Failing health check outputs listed per container
+const healthOutputLines = 3
+
+// containerWatchState is what is known about a container from earlier watch ticks
+type containerWatchState struct {
+	restartCount int
+	restarts     []time.Time
+	health       string
+	looping      bool
+}
+
+// Container health state, kept across watch mode ticks
+var (
+	containerStates        = make(map[string]*containerWatchState)
+	alertedContainerIssues = make(map[string]bool)
+	containerHealthMu      sync.Mutex
+)
+
+// GetContainerHealthInfo displays unhealthy and crash-looping containers
+func GetContainerHealthInfo(opts models.Options) {
+	health, err := CheckContainerHealth(opts)
+	if err != nil {
+		if isDockerNotInstalled(err) {
+			if opts.JSONOutput {
+				fmt.Println("{}")
+			} else {
+				fmt.Println("No container runtime appears to be installed or running.")
+			}
+			return
+		}
+
+		fmt.Printf("Error checking container health: %v\n", err)
+		return
+	}
+
+	if opts.JSONOutput {
+		jsonData, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing container health: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	sections := GetContainerHealthSections(health, opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetContainerHealthSections formats the container health report for the compact display
+func GetContainerHealthSections(health models.ContainerHealth, opts models.Options) map[string][][]string {
+	restartLoop, restartWindow := restartLoopSettings(opts.Docker)
+
+	summary := [][]string{
+		{"Checked", fmt.Sprintf("%d containers", health.Checked)},
+		{"Unhealthy", countStatus(health.Unhealthy)},
+		{"Restart Loops", fmt.Sprintf("%s (%d+ restarts in %s)", countStatus(health.RestartLoops), restartLoop, restartWindow)},
+		{"Health Changes", fmt.Sprintf("%d", health.HealthChanges)},
+	}
+
+	// Restarts and health transitions can only be seen across samples
+	if !opts.InWatchMode {
+		summary = append(summary, []string{"Note", "Use -watch to track restarts and health changes over time"})
+	}
+
+	result := map[string][][]string{
+		"Container Health": summary,
+	}
+
+	if len(health.Issues) == 0 {
+		return result
+	}
+
+	issues := [][]string{
+		{"Issue", "Container", "Health", "Restarts", "Detail"},
+	}
+	output := [][]string{}
+
+	for _, issue := range health.Issues {
+		issues = append(issues, []string{
+			containerIssueLabel(issue.Type),
+			issue.Name,
+			issue.Health,
+			fmt.Sprintf("%d", issue.RestartCount),
+			issue.Detail,
+		})
+
+		for _, line := range issue.FailingOutput {
+			output = append(output, []string{issue.Name, ui.DangerColor(line)})
+		}
+	}
+
+	result["Container Issues"] = issues
+	if len(output) > 0 {
+		result["Health Check Output"] = output
+	}
+
+	return result
+}
+
+// CheckContainerHealth looks for unhealthy containers, health status changes and restart
+// loops since the previous check. New issues raise alerts when alerts are enabled.
+func CheckContainerHealth(opts models.Options) (models.ContainerHealth, error) {
+	// Crash-looping containers are often not running when sampled. Checks only need
+	// states, so resource usage isn't fetched.
+	display := opts.Docker
+	display.All = true
+
+	containers, _, err := collectRuntimeContainers(display, false)
+	if err != nil {
+		return models.ContainerHealth{}, err
+	}
+	containers = latestContainerAttempts(containers)
+
+	restartLoop, restartWindow := restartLoopSettings(opts.Docker)
+
+	containerHealthMu.Lock()
+	defer containerHealthMu.Unlock()
+
+	now := time.Now()
+	health := models.ContainerHealth{
+		Checked: len(containers),
+		Issues:  []models.ContainerIssue{},
+	}
+	seen := make(map[string]bool, len(containers))
+
+	for _, container := range containers {
+		key := containerWatchKey(container)
+		seen[key] = true
+
+		state, known := containerStates[key]
+		if !known {
+			state = &containerWatchState{restartCount: container.RestartCount, health: container.Health}
+			containerStates[key] = state
+		}
+
+		// Each restart seen since the last tick is timestamped now
+		for i := state.restartCount; i < container.RestartCount; i++ {
+			state.restarts = append(state.restarts, now)
+		}
+		state.restartCount = container.RestartCount
+
+		recent := state.restarts[:0]
+		for _, restart := range state.restarts {
+			if now.Sub(restart) <= restartWindow {
+				recent = append(recent, restart)
+			}
+		}
+		state.restarts = recent
+
+		// Without history, a container the daemon is restarting with many restarts
+		// behind it is crash-looping as well, until it stays up. CRI has no restarting
+		// state, the kubelet backs off with the failed attempt left exited.
+		looping := len(state.restarts) >= restartLoop
+		restarting := container.State == "restarting" ||
+			isCRIRuntime(container.Runtime) && container.State == "exited" && container.ExitCode != 0
+		if restarting && (state.looping || !known && container.RestartCount >= restartLoop) {
+			looping = true
+		}
+		state.looping = looping
+		if looping {
+			health.RestartLoops++
+			detail := fmt.Sprintf("%d restarts in the last %s", len(state.restarts), restartWindow)
+			if len(state.restarts) < restartLoop {
+				detail = fmt.Sprintf("restarting, %d restarts so far", container.RestartCount)
+			}
+			if container.ExitCode != 0 {
+				detail += fmt.Sprintf(", last exit code %d", container.ExitCode)
+			}
+			if container.OOMKilled {
+				detail += ", OOM killed"
+			}
+
+			health.Issues = append(health.Issues, models.ContainerIssue{
+				Type:         "restart_loop",
+				ID:           container.ID,
+				Name:         container.Name,
+				Health:       container.Health,
+				RestartCount: container.RestartCount,
+				Restarts:     len(state.restarts),
+				Detail:       detail,
+			})
+		}
+
+		if known && container.Health != state.health && container.Health != "" {
+			health.HealthChanges++
+			previous := state.health
+			if previous == "" {
+				previous = "none"
+			}
+			health.Issues = append(health.Issues, models.ContainerIssue{
+				Type:           "health_change",
+				ID:             container.ID,
+				Name:           container.Name,
+				Health:         container.Health,
+				PreviousHealth: state.health,
+				RestartCount:   container.RestartCount,
+				Detail:         fmt.Sprintf("%s -> %s", previous, container.Health),
+			})
+		}
+
+		previousHealth := ""
+		if known {
+			previousHealth = state.health
+		}
+
+		if container.Health == "unhealthy" {
+			health.Unhealthy++
+			output := failingHealthOutput(container.HealthLog)
+			detail := fmt.Sprintf("%d failed checks in a row", container.FailingStreak)
+			health.Issues = append(health.Issues, models.ContainerIssue{
+				Type:           "unhealthy",
+				ID:             container.ID,
+				Name:           container.Name,
+				Health:         container.Health,
+				PreviousHealth: previousHealth,
+				RestartCount:   container.RestartCount,
+				FailingOutput:  output,
+				Detail:         detail,
+			})
+		}
+
+		state.health = container.Health
+	}
+
+	// Forget removed containers
+	for key := range containerStates {
+		if !seen[key] {
+			delete(containerStates, key)
+		}
+	}
+
+	sort.SliceStable(health.Issues, func(i, j int) bool {
+		return containerIssueRank(health.Issues[i].Type) < containerIssueRank(health.Issues[j].Type)
+	})
+
+	// Alerts print to stdout, where they would break the JSON document
+	if opts.EnableAlerts && !opts.JSONOutput {
+		raiseContainerAlerts(health.Issues, restartLoop, restartWindow)
+	}
+
+	return health, nil
+}
+
+// raiseContainerAlerts sends restart loops and unhealthy containers to the alert manager
+// once for as long as they persist; health changes alert every time they happen
+func raiseContainerAlerts(issues []models.ContainerIssue, restartLoop int, restartWindow time.Duration) {
+	current := make(map[string]bool, len(issues))
+
+	for _, issue := range issues {
+		if issue.Type == "health_change" {
+			// Changes to unhealthy are reported with the unhealthy issue
+			if issue.Health != "unhealthy" {
+				alertManager.CheckContainerHealth(issue.Name, issue.PreviousHealth, issue.Health, "")
+			}
+			continue
+		}
+
+		// Names outlive the containers CRI runtimes replace on every restart
+		id := issue.Type + ":" + issue.Name
+		current[id] = true
+		if alertedContainerIssues[id] {
+			continue
+		}
+		alertedContainerIssues[id] = true
+
+		switch issue.Type {
+		case "restart_loop":
+			if issue.Restarts < restartLoop {
+				alertManager.CheckRestartLoop(issue.Name, issue.RestartCount, restartLoop, 0)
+			} else {
+				alertManager.CheckRestartLoop(issue.Name, issue.Restarts, restartLoop, restartWindow)
+			}
+		case "unhealthy":
+			output := ""
+			if len(issue.FailingOutput) > 0 {
+				output = issue.FailingOutput[len(issue.FailingOutput)-1]
+			}
+			alertManager.CheckContainerHealth(issue.Name, issue.PreviousHealth, issue.Health, output)
+		}
+	}
+
+	// Resolved issues alert again if they come back
+	for id := range alertedContainerIssues {
+		if !current[id] {
+			delete(alertedContainerIssues, id)
+		}
+	}
+}
+
+// containerWatchKey identifies a container across watch ticks. CRI runtimes replace a
+// Kubernetes container on every restart and count restarts as attempts, so their
+// containers are tracked by pod and name rather than by ID.
+func containerWatchKey(container models.ContainerInfo) string {
+	if isCRIRuntime(container.Runtime) && container.PodUID != "" {
+		return container.PodUID + "/" + container.Name
+	}
+	return container.ID
+}
+
+// latestContainerAttempts drops containers that a later attempt of the same Kubernetes
+// container has replaced, keeping the order of the others
+func latestContainerAttempts(containers []models.ContainerInfo) []models.ContainerInfo {
+	latest := make(map[string]int, len(containers))
+	for i, container := range containers {
+		key := containerWatchKey(container)
+		if j, ok := latest[key]; !ok || container.RestartCount > containers[j].RestartCount {
+			latest[key] = i
+		}
+	}
+
+	result := make([]models.ContainerInfo, 0, len(latest))
+	for i, container := range containers {
+		if latest[containerWatchKey(container)] == i {
+			result = append(result, container)
+		}
+	}
+	return result
+}
+
+// failingHealthOutput returns the output of the most recent failed health checks, oldest first
+func failingHealthOutput(log []models.HealthCheckResult) []string {
+	output := []string{}
+	for i := len(log) - 1; i >= 0 && len(output) < healthOutputLines; i-- {
+		check := log[i]
+		if check.ExitCode == 0 {
+			continue
+		}
+
+		// Keep the first line, health check scripts tend to print usage dumps
+		line, _, _ := strings.Cut(check.Output, "\n")
+		if len(line) > 100 {
+			line = line[:97] + "..."
+		}
+		if line == "" {
+			line = "(no output)"
+		}
+		output = append([]string{fmt.Sprintf("exit %d: %s", check.ExitCode, line)}, output...)
+	}
+	return output
+}
+
+// restartLoopSettings returns the restart loop threshold and window, with defaults
+func restartLoopSettings(display models.DockerDisplay) (int, time.Duration) {
+	restartLoop := display.RestartLoop
+	if restartLoop <= 0 {
+		restartLoop = DefaultRestartLoop
+	}
+	restartWindow := display.RestartWindow
+	if restartWindow <= 0 {
+		restartWindow = DefaultRestartWindow
+	}
+	return restartLoop, restartWindow
+}
+
+// containerIssueRank orders issues with the most urgent types first
+func containerIssueRank(issueType string) int {
+	switch issueType {
+	case "restart_loop":
+		return 0
+	case "unhealthy":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// containerIssueLabel returns the display name of an issue type
+func containerIssueLabel(issueType string) string {
+	switch issueType {
+	case "restart_loop":
+		return "Restart loop"
+	case "unhealthy":
+		return "Unhealthy"
+	case "health_change":
+		return "Health change"
+	}
+	return issueType
+}
@@ -0,0 +1,370 @@
+package collectors
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Files container engines create in the root filesystem of their containers
+var containerEnvFiles = []struct{ path, runtime string }{
+	{"/.dockerenv", RuntimeDocker},
+	{"/run/.containerenv", RuntimePodman},
+}
+
+// Parts of a cgroup path that show a process runs in a container
+var containerCgroupMarkers = []struct{ marker, runtime string }{
+	{"kubepods", "kubernetes"},
+	{"docker", RuntimeDocker},
+	{"libpod", RuntimePodman},
+	{"containerd", RuntimeContainerd},
+	{"crio", "cri-o"},
+	{"lxc", "lxc"},
+}
+
+// cgroup v1 limits at or above this are the kernel's "unlimited" (page-aligned 2^63-1)
+const cgroupV1Unlimited = 1 << 60
+
+// selfCgroup locates the cgroups whosay runs in. Each directory list runs from whosay's
+// own cgroup up to the mount root, as limits of parent cgroups apply as well.
+type selfCgroup struct {
+	runtime    string
+	v2         bool
+	cpuDirs    []string
+	cpuacctDir string // cgroup v1 only
+	memoryDirs []string
+}
+
+// containerCPUSample holds the cumulative CPU counters of whosay's cgroup
+type containerCPUSample struct {
+	usageUsec        uint64
+	periods          uint64
+	throttledPeriods uint64
+	readAt           time.Time
+}
+
+// The container whosay runs in, detected once
+var (
+	selfCgroupInfo *selfCgroup
+	selfCgroupOnce sync.Once
+)
+
+// detectSelfContainer returns the cgroups of the container whosay runs in, or nil when it
+// doesn't run in one. Kubernetes sets KUBERNETES_SERVICE_HOST in every pod, engines leave
+// marker files, and container cgroups are named after the engine.
+func detectSelfContainer() *selfCgroup {
+	selfCgroupOnce.Do(func() {
+		if runtime.GOOS != "linux" {
+			return
+		}
+
+		paths, err := readSelfCgroupPaths()
+		if err != nil {
+			return
+		}
+
+		containerRuntime := ""
+		if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+			containerRuntime = "kubernetes"
+		}
+		for _, envFile := range containerEnvFiles {
+			if containerRuntime != "" {
+				break
+			}
+			if _, err := os.Stat(envFile.path); err == nil {
+				containerRuntime = envFile.runtime
+			}
+		}
+		for _, marker := range containerCgroupMarkers {
+			if containerRuntime != "" {
+				break
+			}
+			for _, path := range paths {
+				if strings.Contains(path, marker.marker) {
+					containerRuntime = marker.runtime
+					break
+				}
+			}
+		}
+		if containerRuntime == "" {
+			return
+		}
+
+		cgroup := &selfCgroup{runtime: containerRuntime}
+		if path, ok := paths[""]; ok {
+			if root, err := cgroupV2Root(); err == nil {
+				// Hybrid hosts mount a v2 hierarchy without the cpu and memory controllers
+				dirs := cgroupAncestors(root, path)
+				if fileExists(filepath.Join(dirs[0], "cpu.stat")) && fileExists(filepath.Join(dirs[0], "memory.current")) {
+					cgroup.v2 = true
+					cgroup.cpuDirs = dirs
+					cgroup.memoryDirs = dirs
+				}
+			}
+		}
+		if !cgroup.v2 {
+			cgroup.cpuDirs = cgroupV1Dirs(paths, "cpu")
+			cgroup.memoryDirs = cgroupV1Dirs(paths, "memory")
+			if dirs := cgroupV1Dirs(paths, "cpuacct"); len(dirs) > 0 {
+				cgroup.cpuacctDir = dirs[0]
+			}
+		}
+
+		selfCgroupInfo = cgroup
+	})
+	return selfCgroupInfo
+}
+
+// readSelfCgroupPaths reads /proc/self/cgroup into cgroup paths keyed by controller.
+// The cgroup v2 path has the key "".
+func readSelfCgroupPaths() (map[string]string, error) {
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		if parts[0] == "0" && parts[1] == "" {
+			paths[""] = parts[2]
+			continue
+		}
+		for _, controller := range strings.Split(parts[1], ",") {
+			paths[controller] = parts[2]
+		}
+	}
+	return paths, nil
+}
+
+// cgroupV1Dirs returns the cgroup directories of a v1 controller, whose hierarchy is
+// mounted on its own or together with a related controller
+func cgroupV1Dirs(paths map[string]string, controller string) []string {
+	path, ok := paths[controller]
+	if !ok {
+		return nil
+	}
+
+	for _, mount := range []string{controller, "cpu,cpuacct", "cpuacct,cpu"} {
+		root := filepath.Join("/sys/fs/cgroup", mount)
+		if fileExists(filepath.Join(root, "cgroup.procs")) {
+			return cgroupAncestors(root, path)
+		}
+	}
+	return nil
+}
+
+// cgroupAncestors lists a cgroup's directory and its parents up to the mount root. With a
+// cgroup namespace, the process's own cgroup is the mount root and its path isn't found.
+func cgroupAncestors(root, path string) []string {
+	dir := filepath.Join(root, filepath.FromSlash(path))
+	if !fileExists(dir) || !strings.HasPrefix(dir, root) {
+		dir = root
+	}
+
+	dirs := []string{dir}
+	for dir != root {
+		dir = filepath.Dir(dir)
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// readCPULimit returns the tightest CPU quota along the cgroup tree in cores, 0 when unlimited
+func (c *selfCgroup) readCPULimit() float64 {
+	limit := 0.0
+	for _, dir := range c.cpuDirs {
+		cores := 0.0
+		if c.v2 {
+			cores = readCgroupCPULimit(dir)
+		} else {
+			quota, err := readCgroupInt(filepath.Join(dir, "cpu.cfs_quota_us"))
+			period, periodErr := readCgroupInt(filepath.Join(dir, "cpu.cfs_period_us"))
+			if err == nil && periodErr == nil && quota > 0 && period > 0 {
+				cores = float64(quota) / float64(period)
+			}
+		}
+		if cores > 0 && (limit == 0 || cores < limit) {
+			limit = cores
+		}
+	}
+	return limit
+}
+
+// readCPUSample reads the cgroup's CPU time and throttling counters
+func (c *selfCgroup) readCPUSample() (containerCPUSample, bool) {
+	if len(c.cpuDirs) == 0 {
+		return containerCPUSample{}, false
+	}
+
+	sample := containerCPUSample{readAt: time.Now()}
+	stat, err := readCgroupKeyValues(filepath.Join(c.cpuDirs[0], "cpu.stat"))
+	if err == nil {
+		sample.periods = stat["nr_periods"]
+		sample.throttledPeriods = stat["nr_throttled"]
+	}
+
+	if c.v2 {
+		if err != nil {
+			return containerCPUSample{}, false
+		}
+		sample.usageUsec = stat["usage_usec"]
+		return sample, true
+	}
+
+	// cpuacct.usage is in nanoseconds
+	if c.cpuacctDir == "" {
+		return containerCPUSample{}, false
+	}
+	usage, err := readCgroupInt(filepath.Join(c.cpuacctDir, "cpuacct.usage"))
+	if err != nil {
+		return containerCPUSample{}, false
+	}
+	sample.usageUsec = uint64(usage) / 1000
+	return sample, true
+}
+
+// readMemory returns the cgroup's working set, i.e. usage without inactive page cache like
+// `kubectl top`, and the tightest memory limit along the cgroup tree, 0 when unlimited
+func (c *selfCgroup) readMemory() (uint64, uint64, bool) {
+	if len(c.memoryDirs) == 0 {
+		return 0, 0, false
+	}
+
+	usageFile, cacheKey := "memory.usage_in_bytes", "total_inactive_file"
+	if c.v2 {
+		usageFile, cacheKey = "memory.current", "inactive_file"
+	}
+
+	usage, err := readCgroupValue(filepath.Join(c.memoryDirs[0], usageFile))
+	if err != nil {
+		return 0, 0, false
+	}
+	if stat, err := readCgroupKeyValues(filepath.Join(c.memoryDirs[0], "memory.stat")); err == nil {
+		if cache := stat[cacheKey]; cache < usage {
+			usage -= cache
+		}
+	}
+
+	limit := uint64(0)
+	for _, dir := range c.memoryDirs {
+		value := uint64(0)
+		if c.v2 {
+			value, _ = readCgroupValue(filepath.Join(dir, "memory.max"))
+		} else if v1, err := readCgroupValue(filepath.Join(dir, "memory.limit_in_bytes")); err == nil && v1 < cgroupV1Unlimited {
+			value = v1
+		}
+		if value > 0 && (limit == 0 || value < limit) {
+			limit = value
+		}
+	}
+
+	return usage, limit, true
+}
+
+// containerCPUUsage computes usage relative to the container's CPU limit between two samples
+func containerCPUUsage(cgroup *selfCgroup, prev, current containerCPUSample) *models.ContainerCPUUsage {
+	usage := &models.ContainerCPUUsage{
+		Runtime:    cgroup.runtime,
+		LimitCores: cgroup.readCPULimit(),
+	}
+
+	elapsed := current.readAt.Sub(prev.readAt).Microseconds()
+	if elapsed > 0 {
+		usage.UsageCores = float64(counterDelta(prev.usageUsec, current.usageUsec)) / float64(elapsed)
+	}
+	if usage.LimitCores > 0 {
+		usage.LimitPerc = usage.UsageCores / usage.LimitCores * 100
+	}
+	if periods := counterDelta(prev.periods, current.periods); periods > 0 {
+		usage.ThrottledPercent = float64(counterDelta(prev.throttledPeriods, current.throttledPeriods)) / float64(periods) * 100
+	}
+	return usage
+}
+
+// collectContainerMemory returns memory usage relative to the limit of the container
+// whosay runs in, or nil outside a container
+func collectContainerMemory() *models.ContainerMemoryUsage {
+	cgroup := detectSelfContainer()
+	if cgroup == nil {
+		return nil
+	}
+
+	used, limit, ok := cgroup.readMemory()
+	if !ok {
+		return nil
+	}
+
+	usage := &models.ContainerMemoryUsage{
+		Runtime: cgroup.runtime,
+		Limit:   limit,
+		Used:    used,
+	}
+	if limit > 0 {
+		usage.LimitPerc = float64(used) / float64(limit) * 100
+	}
+	return usage
+}
+
+// getContainerCPURows formats CPU usage against the container's limit, next to the host's cores
+func getContainerCPURows(usage models.ContainerCPUUsage, hostCPUs int, barWidth int) [][]string {
+	if usage.LimitCores == 0 {
+		return [][]string{
+			{"Container", fmt.Sprintf("%s, no CPU limit", usage.Runtime)},
+			{"Container Use", fmt.Sprintf("%.2f cores of %d on the host", usage.UsageCores, hostCPUs)},
+		}
+	}
+
+	rows := [][]string{
+		{"Container", fmt.Sprintf("%s, limit %.2f of %d cores", usage.Runtime, usage.LimitCores, hostCPUs)},
+		{"Container Use", fmt.Sprintf("%.2f cores (%s of limit)", usage.UsageCores, ui.FormatPercent(usage.LimitPerc))},
+		{"", ui.PrintCompactUsageBar("", usage.LimitPerc, barWidth)},
+	}
+	if usage.ThrottledPercent > 0 {
+		rows = append(rows, []string{"Throttled", ui.WarningColor(fmt.Sprintf("%.1f%% of periods", usage.ThrottledPercent))})
+	}
+	return rows
+}
+
+// getContainerMemoryRows formats memory usage against the container's limit, next to the host's total
+func getContainerMemoryRows(usage models.ContainerMemoryUsage, hostTotal uint64, barWidth int) [][]string {
+	if usage.Limit == 0 {
+		return [][]string{
+			{"Container", fmt.Sprintf("%s, no memory limit", usage.Runtime)},
+			{"Container Use", formatBytes(usage.Used)},
+		}
+	}
+
+	return [][]string{
+		{"Container", fmt.Sprintf("%s, limit %s of %s", usage.Runtime, formatBytes(usage.Limit), formatBytes(hostTotal))},
+		{"Container Use", fmt.Sprintf("%s (%s of limit)", formatBytes(usage.Used), ui.FormatPercent(usage.LimitPerc))},
+		{"Limit Usage", ui.PrintCompactUsageBar("", usage.LimitPerc, barWidth)},
+	}
+}
+
+// readCgroupInt reads a single signed value, as cgroup v1 writes -1 for no quota
+func readCgroupInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+}
+
+// fileExists reports whether a path exists
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -1,41 +1,87 @@
 package collectors
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/fatih/color"
+	"github.com/tiwariParth/whosay/internal/docker"
 	"github.com/tiwariParth/whosay/internal/models"
 	"github.com/tiwariParth/whosay/internal/ui"
 )
 
-// GetContainerLogs displays logs from a specified Docker container
-func GetContainerLogs(containerID string, tailLines int, opts models.Options) {
-	logs, err := fetchContainerLogs(containerID, tailLines)
+// How long follow mode holds log lines so lines from several containers can be
+// printed in timestamp order
+const logMergeWindow = 300 * time.Millisecond
+
+// Prefix colors for containers in merged log output
+var logPrefixColors = []func(a ...interface{}) string{
+	color.New(color.FgHiCyan).SprintFunc(),
+	color.New(color.FgHiMagenta).SprintFunc(),
+	color.New(color.FgHiGreen).SprintFunc(),
+	color.New(color.FgHiBlue).SprintFunc(),
+	color.New(color.FgHiYellow).SprintFunc(),
+	color.New(color.FgCyan).SprintFunc(),
+	color.New(color.FgMagenta).SprintFunc(),
+	color.New(color.FgGreen).SprintFunc(),
+}
+
+// GetContainerLogs displays logs from one or more containers. The target is a
+// comma-separated list of container names or IDs, label=KEY[=VALUE] selectors and
+// project=NAME compose project selectors; logs of several containers are merged by timestamp.
+func GetContainerLogs(target string, tailLines int, opts models.Options) {
+	display := opts.Logs
+	display.Limit = tailLines
+
+	runtime, err := getContainerRuntime()
 	if err != nil {
 		fmt.Printf("Error fetching container logs: %v\n", err)
 		return
 	}
 
-	if opts.JSONOutput {
-		// Format logs as JSON for programmatic consumption
-		type logEntry struct {
-			Container string    `json:"container"`
-			Timestamp time.Time `json:"timestamp,omitempty"`
-			Message   string    `json:"message"`
+	resolveCtx, cancel := context.WithTimeout(context.Background(), dockerRequestTimeout)
+	containers, err := resolveLogTargets(resolveCtx, runtime, target)
+	cancel()
+	if err != nil {
+		fmt.Printf("Error fetching container logs: %v\n", err)
+		return
+	}
+
+	if display.Follow {
+		if err := followContainerLogs(runtime, containers, display, opts); err != nil {
+			fmt.Printf("Error following container logs: %v\n", err)
 		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerRequestTimeout)
+	defer cancel()
+
+	logs, err := fetchMergedLogs(ctx, runtime, containers, display)
+	if err != nil {
+		fmt.Printf("Error fetching container logs: %v\n", err)
+		return
+	}
 
-		entries := make([]logEntry, len(logs))
-		for i, log := range logs {
-			entries[i] = logEntry{
-				Container: containerID,
-				Message:   log,
+	if opts.JSONOutput {
+		if display.NDJSON {
+			encoder := json.NewEncoder(os.Stdout)
+			for _, entry := range logs {
+				encoder.Encode(entry)
 			}
+			return
 		}
 
-		jsonData, err := json.MarshalIndent(entries, "", "  ")
+		// Format logs as JSON for programmatic consumption
+		jsonData, err := json.MarshalIndent(logs, "", "  ")
 		if err != nil {
 			fmt.Printf("Error serializing log data: %v\n", err)
 			return
@@ -45,48 +91,51 @@ func GetContainerLogs(containerID string, tailLines int, opts models.Options) {
 	}
 
 	// Display logs in a pretty format
-	sections := GetContainerLogSections(containerID, logs, opts)
+	sections := GetContainerLogSections(containers, logs, opts)
 	ui.CompactDisplay(sections)
 }
 
-// GetContainerLogSections formats container logs for display
-func GetContainerLogSections(containerID string, logs []string, opts models.Options) map[string][][]string {
-	// Get container name if we have a container ID
-	containerName := containerID
-	if len(containerID) >= 12 {
-		// Try to get the actual container name
-		nameCmd := exec.Command("docker", "inspect", "--format", "{{.Name}}", containerID)
-		nameOutput, err := nameCmd.Output()
-		if err == nil {
-			name := strings.TrimSpace(string(nameOutput))
-			// Remove leading slash from container name
-			if strings.HasPrefix(name, "/") {
-				name = name[1:]
-			}
-			if name != "" {
-				containerName = name
-			}
+// GetContainerLogSections formats container logs for display. Lines of several
+// containers get a colored container name prefix.
+func GetContainerLogSections(containers []models.ContainerInfo, logs []models.ContainerLogEntry, opts models.Options) map[string][][]string {
+	// Create header for the logs section
+	logData := [][]string{}
+	if len(containers) == 1 {
+		logData = append(logData,
+			[]string{"Container", containers[0].Name},
+			[]string{"ID", containers[0].ID},
+		)
+	} else {
+		names := make([]string, len(containers))
+		for i, container := range containers {
+			names[i] = container.Name
 		}
+		logData = append(logData, []string{"Containers", strings.Join(names, ", ")})
 	}
+	logData = append(logData, []string{"Lines", fmt.Sprintf("%d", len(logs))})
 
-	// Create header for the logs section
-	logData := [][]string{
-		{"Container", containerName},
-		{"ID", containerID},
-		{"Lines", fmt.Sprintf("%d", len(logs))},
-		{"", ""}, // Spacer
+	if opts.Logs.Include != "" {
+		logData = append(logData, []string{"Include", opts.Logs.Include})
+	}
+	if opts.Logs.Exclude != "" {
+		logData = append(logData, []string{"Exclude", opts.Logs.Exclude})
 	}
+	logData = append(logData, []string{"", ""}) // Spacer
+
+	prefix := newLogPrefixer(containers)
 
 	// Add log lines
-	for _, line := range logs {
-		// Color code log lines based on content
-		coloredLine := formatLogLine(line)
-		logData = append(logData, []string{"", coloredLine})
+	for _, entry := range logs {
+		line := prefix(entry.Container) + formatLogMessage(entry)
+		if !entry.Timestamp.IsZero() {
+			line = ui.DimColor(entry.Timestamp.Local().Format("15:04:05")) + " " + line
+		}
+		logData = append(logData, []string{"", line})
 	}
 
 	// If no logs found
 	if len(logs) == 0 {
-		logData = append(logData, []string{"", "No logs found for this container"})
+		logData = append(logData, []string{"", "No logs found for the selected containers"})
 	}
 
 	// Build the final sections map
@@ -95,40 +144,392 @@ func GetContainerLogSections(containerID string, logs []string, opts models.Opti
 	}
 }
 
-// fetchContainerLogs gets logs from a Docker container
-func fetchContainerLogs(containerID string, tailLines int) ([]string, error) {
-	// Validate container ID/name exists
-	checkCmd := exec.Command("docker", "container", "inspect", containerID)
-	if err := checkCmd.Run(); err != nil {
-		return nil, fmt.Errorf("container '%s' not found: %w", containerID, err)
+// newLogPrefixer returns a function giving each container a padded, colored name
+// prefix like `docker compose logs`. A single container gets no prefix.
+func newLogPrefixer(containers []models.ContainerInfo) func(name string) string {
+	if len(containers) < 2 {
+		return func(string) string { return "" }
+	}
+
+	width := 0
+	colors := make(map[string]func(a ...interface{}) string, len(containers))
+	for i, container := range containers {
+		if len(container.Name) > width {
+			width = len(container.Name)
+		}
+		colors[container.Name] = logPrefixColors[i%len(logPrefixColors)]
+	}
+
+	return func(name string) string {
+		colorFunc, ok := colors[name]
+		if !ok {
+			colorFunc = ui.DimColor
+		}
+		return colorFunc(fmt.Sprintf("%-*s |", width, name)) + " "
+	}
+}
+
+// resolveLogTargets turns a comma-separated target list into containers, in the order given
+func resolveLogTargets(ctx context.Context, runtime ContainerRuntime, target string) ([]models.ContainerInfo, error) {
+	containers := []models.ContainerInfo{}
+	seen := make(map[string]bool)
+
+	add := func(container models.ContainerInfo) {
+		if !seen[container.ID] {
+			seen[container.ID] = true
+			containers = append(containers, container)
+		}
+	}
+
+	for _, item := range strings.Split(target, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		label := ""
+		switch {
+		case strings.HasPrefix(item, "label="):
+			label = strings.TrimPrefix(item, "label=")
+		case strings.HasPrefix(item, "project="):
+			label = composeProjectLabel + "=" + strings.TrimPrefix(item, "project=")
+		default:
+			container, err := runtime.FindContainer(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			add(container)
+			continue
+		}
+
+		// Stopped containers are included, their logs are often the interesting ones
+		matches, err := runtime.FindContainersByLabel(ctx, label)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no containers match '%s'", item)
+		}
+
+		sort.Slice(matches, func(i, j int) bool {
+			return matches[i].Name < matches[j].Name
+		})
+		for _, match := range matches {
+			add(match)
+		}
+	}
+
+	if len(containers) == 0 {
+		return nil, fmt.Errorf("no container given")
+	}
+	return containers, nil
+}
+
+// fetchMergedLogs gets the last log lines of each container concurrently and merges
+// them by timestamp. The limit applies per container, like `docker compose logs --tail`.
+func fetchMergedLogs(ctx context.Context, runtime ContainerRuntime, containers []models.ContainerInfo, display models.LogDisplay) ([]models.ContainerLogEntry, error) {
+	results := make([][]models.ContainerLogEntry, len(containers))
+	errs := make([]error, len(containers))
+
+	var wg sync.WaitGroup
+	for i, container := range containers {
+		wg.Add(1)
+		go func(i int, container models.ContainerInfo) {
+			defer wg.Done()
+			results[i], errs[i] = fetchContainerLogs(ctx, runtime, container, display)
+		}(i, container)
 	}
+	wg.Wait()
 
-	// Construct command to fetch logs
-	tailArg := "all"
-	if tailLines > 0 {
-		tailArg = fmt.Sprintf("%d", tailLines)
+	merged := []models.ContainerLogEntry{}
+	for i, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+		merged = append(merged, results[i]...)
 	}
 
-	cmd := exec.Command("docker", "logs", "--tail", tailArg, containerID)
-	output, err := cmd.Output()
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Timestamp.Before(merged[j].Timestamp)
+	})
+	return merged, nil
+}
+
+// fetchContainerLogs gets the last log lines of a container
+func fetchContainerLogs(ctx context.Context, runtime ContainerRuntime, container models.ContainerInfo, display models.LogDisplay) ([]models.ContainerLogEntry, error) {
+	filter, err := compileLogFilter(display)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch logs from container '%s': %w", containerID, err)
+		return nil, err
 	}
 
-	// Split logs into lines
-	logs := strings.Split(string(output), "\n")
-	
-	// Remove empty trailing line if present
-	if len(logs) > 0 && logs[len(logs)-1] == "" {
-		logs = logs[:len(logs)-1]
+	// Filters are applied here, so the limit can only be applied server-side without them
+	tail := "all"
+	if display.Limit > 0 && !filter.active() {
+		tail = fmt.Sprintf("%d", display.Limit)
+	}
+
+	logs := []models.ContainerLogEntry{}
+	err = readContainerLogs(ctx, runtime, container, docker.LogOptions{
+		Tail:  tail,
+		Since: display.Since,
+		Until: display.Until,
+	}, filter, func(entry models.ContainerLogEntry) error {
+		logs = append(logs, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch logs from container '%s': %w", container.Name, err)
+	}
+
+	if display.Limit > 0 && len(logs) > display.Limit {
+		logs = logs[len(logs)-display.Limit:]
 	}
 
 	return logs, nil
 }
 
+// followContainerLogs prints log lines of the containers as they arrive until all of them
+// stop or the -until time passes. Lines are held for a short window so lines from several
+// containers come out in timestamp order. JSON output is written as one object per line.
+func followContainerLogs(runtime ContainerRuntime, containers []models.ContainerInfo, display models.LogDisplay, opts models.Options) error {
+	filter, err := compileLogFilter(display)
+	if err != nil {
+		return err
+	}
+
+	tail := "all"
+	if display.Limit > 0 {
+		tail = fmt.Sprintf("%d", display.Limit)
+	}
+
+	entries := make(chan models.ContainerLogEntry, 256)
+	errs := make(chan error, len(containers))
+	var wg sync.WaitGroup
+
+	for _, container := range containers {
+		wg.Add(1)
+		go func(container models.ContainerInfo) {
+			defer wg.Done()
+			err := readContainerLogs(context.Background(), runtime, container, docker.LogOptions{
+				Follow: true,
+				Tail:   tail,
+				Since:  display.Since,
+				Until:  display.Until,
+			}, filter, func(entry models.ContainerLogEntry) error {
+				entries <- entry
+				return nil
+			})
+			if err != nil {
+				errs <- fmt.Errorf("%s: %w", container.Name, err)
+			}
+		}(container)
+	}
+
+	go func() {
+		wg.Wait()
+		close(entries)
+	}()
+
+	prefix := newLogPrefixer(containers)
+	encoder := json.NewEncoder(os.Stdout)
+
+	emit := func(entry models.ContainerLogEntry) {
+		if opts.JSONOutput {
+			encoder.Encode(entry)
+			return
+		}
+		fmt.Println(formatFollowedLogLine(entry, prefix(entry.Container)))
+	}
+
+	// A single container needs no reordering
+	if len(containers) == 1 {
+		for entry := range entries {
+			emit(entry)
+		}
+	} else {
+		mergeLogStream(entries, logMergeWindow, emit)
+	}
+
+	close(errs)
+	for err := range errs {
+		return err
+	}
+	return nil
+}
+
+// mergeLogStream buffers entries for the window and emits them in timestamp order
+func mergeLogStream(entries <-chan models.ContainerLogEntry, window time.Duration, emit func(models.ContainerLogEntry)) {
+	type pendingEntry struct {
+		entry   models.ContainerLogEntry
+		arrived time.Time
+	}
+
+	pending := []pendingEntry{}
+	ticker := time.NewTicker(window / 3)
+	defer ticker.Stop()
+
+	flush := func(all bool) {
+		sort.SliceStable(pending, func(i, j int) bool {
+			return pending[i].entry.Timestamp.Before(pending[j].entry.Timestamp)
+		})
+
+		// Emit in timestamp order up to the first entry still inside its window
+		cutoff := time.Now().Add(-window)
+		emitted := 0
+		for _, p := range pending {
+			if !all && p.arrived.After(cutoff) {
+				break
+			}
+			emit(p.entry)
+			emitted++
+		}
+		pending = pending[emitted:]
+	}
+
+	for {
+		select {
+		case entry, ok := <-entries:
+			if !ok {
+				flush(true)
+				return
+			}
+			pending = append(pending, pendingEntry{entry: entry, arrived: time.Now()})
+		case <-ticker.C:
+			flush(false)
+		}
+	}
+}
+
+// ValidateLogDisplay checks the include and exclude expressions of the log options
+func ValidateLogDisplay(display models.LogDisplay) error {
+	if _, err := compileLogFilter(display); err != nil {
+		return err
+	}
+
+	if !display.Since.IsZero() && !display.Until.IsZero() && display.Until.Before(display.Since) {
+		return fmt.Errorf("-until must not be before -since")
+	}
+
+	return nil
+}
+
+// ParseLogTime parses a -since or -until value: a duration before now (10m, 2h),
+// an RFC 3339 time, a date (2006-01-02) or a Unix timestamp
+func ParseLogTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+	if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return parsed, nil
+	}
+	if parsed, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return parsed, nil
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return time.Unix(0, int64(seconds*float64(time.Second))), nil
+	}
+
+	return time.Time{}, fmt.Errorf("'%s' is not a duration, RFC 3339 time, date or Unix timestamp", value)
+}
+
+// logFilter keeps log lines matching the include expression and not matching the exclude one
+type logFilter struct {
+	include *regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+// compileLogFilter builds the filter for the include and exclude expressions of the log options
+func compileLogFilter(display models.LogDisplay) (logFilter, error) {
+	filter := logFilter{}
+
+	if display.Include != "" {
+		include, err := regexp.Compile(display.Include)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include expression: %v", err)
+		}
+		filter.include = include
+	}
+
+	if display.Exclude != "" {
+		exclude, err := regexp.Compile(display.Exclude)
+		if err != nil {
+			return filter, fmt.Errorf("invalid exclude expression: %v", err)
+		}
+		filter.exclude = exclude
+	}
+
+	return filter, nil
+}
+
+// active reports whether any expression is set
+func (f logFilter) active() bool {
+	return f.include != nil || f.exclude != nil
+}
+
+// matches reports whether a log message passes the filter
+func (f logFilter) matches(message string) bool {
+	if f.include != nil && !f.include.MatchString(message) {
+		return false
+	}
+	if f.exclude != nil && f.exclude.MatchString(message) {
+		return false
+	}
+	return true
+}
+
+// readContainerLogs streams a container's logs with timestamps and calls fn for each
+// entry that passes the filter
+func readContainerLogs(ctx context.Context, runtime ContainerRuntime, container models.ContainerInfo, options docker.LogOptions,
+	filter logFilter, fn func(models.ContainerLogEntry) error) error {
+	options.Timestamps = true
+
+	return runtime.ReadLogs(ctx, container.ID, options, func(streamName, line string) error {
+		entry := parseLogLine(line)
+		entry.Container = container.Name
+		entry.Stream = streamName
+
+		if !filter.matches(entry.Message) {
+			return nil
+		}
+		return fn(entry)
+	})
+}
+
+// parseLogLine splits the RFC 3339 timestamp the runtime puts in front of each line
+func parseLogLine(line string) models.ContainerLogEntry {
+	if prefix, message, found := strings.Cut(line, " "); found {
+		if timestamp, err := time.Parse(time.RFC3339Nano, prefix); err == nil {
+			return models.ContainerLogEntry{Timestamp: timestamp, Message: message}
+		}
+	}
+	return models.ContainerLogEntry{Message: line}
+}
+
+// formatLogMessage colors a log message and tags lines written to stderr
+func formatLogMessage(entry models.ContainerLogEntry) string {
+	if entry.Stream == "stderr" {
+		return ui.DangerColor("[err] ") + formatLogLine(entry.Message)
+	}
+	return ui.DimColor("[out] ") + formatLogLine(entry.Message)
+}
+
+// formatFollowedLogLine formats a log entry as a single line for follow mode
+func formatFollowedLogLine(entry models.ContainerLogEntry, prefix string) string {
+	timestamp := ""
+	if !entry.Timestamp.IsZero() {
+		timestamp = ui.DimColor(entry.Timestamp.Local().Format("2006-01-02 15:04:05.000")) + " "
+	}
+	return timestamp + prefix + formatLogMessage(entry)
+}
+
 // ListContainers lists all running containers
 func ListContainers() ([]models.ContainerInfo, error) {
-	return GetDockerContainers()
+	return GetDockerContainers(models.DockerDisplay{})
 }
 
 // formatLogLine applies colors to log line based on content
@@ -1,16 +1,47 @@
 package collectors
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"os"
+	"os/exec"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/tiwariParth/whosay/internal/models"
 	"github.com/tiwariParth/whosay/internal/ui"
 )
 
+// Interval used for the second reading when no previous CPU sample exists
+const cpuSampleInterval = 250 * time.Millisecond
+
+// cpuTimes holds the cumulative jiffy counters of a /proc/stat cpu line
+type cpuTimes struct {
+	User    uint64
+	Nice    uint64
+	System  uint64
+	Idle    uint64
+	IOWait  uint64
+	IRQ     uint64
+	SoftIRQ uint64
+	Steal   uint64
+}
+
+// CPU sample store, kept across watch mode ticks
+var (
+	lastCPUTimes     cpuTimes
+	lastCoreTimes    map[int]cpuTimes
+	lastContainerCPU containerCPUSample
+	hasCPUSample     bool
+	cpuStatMu        sync.Mutex
+)
+
 // GetCPUInfo displays CPU information
 func GetCPUInfo(opts models.Options) {
 	info := collectCPUInfo()
@@ -33,6 +64,13 @@ func GetCPUInfoSections(opts models.Options) map[string][][]string {
 		{"CPUs", fmt.Sprintf("%d cores", info.NumCPU)},
 		{"Architecture", getArchDescription(info.Architecture)},
 		{"Usage", fmt.Sprintf("%.1f%%", info.Usage)},
+		{"Breakdown", fmt.Sprintf("usr %.1f%% / sys %.1f%% / iowait %.1f%% / idle %.1f%%",
+			info.User, info.System, info.IOWait, info.Idle)},
+	}
+	
+	// Steal time only matters on virtual machines, so hide it unless present
+	if info.Steal > 0 || opts.VerboseOutput {
+		cpuData = append(cpuData, []string{"Steal", fmt.Sprintf("%.1f%%", info.Steal)})
 	}
 	
 	barWidth := 20
@@ -44,27 +82,297 @@ func GetCPUInfoSections(opts models.Options) map[string][][]string {
 		"", ui.PrintCompactUsageBar("", info.Usage, barWidth),
 	})
 	
-	return map[string][][]string{
+	// Host usage says little about headroom when whosay runs under a container's limit
+	if info.Container != nil {
+		cpuData = append(cpuData, getContainerCPURows(*info.Container, info.NumCPU, barWidth)...)
+	}
+	
+	result := map[string][][]string{
 		"CPU": cpuData,
 	}
+	
+	if len(info.Cores) > 1 {
+		result["CPU Cores"] = getCPUCoreRows(info.Cores, opts)
+	}
+	
+	return result
+}
+
+// getCPUCoreRows renders the per-core heat grid and the busiest cores
+func getCPUCoreRows(cores []models.CPUCoreInfo, opts models.Options) [][]string {
+	usages := make([]float64, len(cores))
+	for i, core := range cores {
+		usages[i] = core.Usage
+	}
+	
+	columns := 16
+	if opts.CompactMode {
+		columns = 8
+	}
+	
+	coreData := [][]string{}
+	for _, row := range ui.RenderHeatGrid(usages, columns) {
+		coreData = append(coreData, []string{"", row})
+	}
+	
+	// List the busiest cores so single-threaded hotspots stand out
+	hottest := make([]models.CPUCoreInfo, len(cores))
+	copy(hottest, cores)
+	sort.Slice(hottest, func(i, j int) bool {
+		return hottest[i].Usage > hottest[j].Usage
+	})
+	
+	hotCount := 3
+	if opts.VerboseOutput {
+		hotCount = 8
+	}
+	if hotCount > len(hottest) {
+		hotCount = len(hottest)
+	}
+	
+	hotList := make([]string, 0, hotCount)
+	for _, core := range hottest[:hotCount] {
+		hotList = append(hotList, fmt.Sprintf("cpu%d %.1f%%", core.ID, core.Usage))
+	}
+	coreData = append(coreData, []string{"Busiest", strings.Join(hotList, ", ")})
+	
+	return coreData
 }
 
 // collectCPUInfo gathers CPU information
 func collectCPUInfo() models.CPUInfo {
-	return models.CPUInfo{
+	info := models.CPUInfo{
 		NumCPU:       runtime.NumCPU(),
-		Usage:        getCPUUsage(),
 		Architecture: runtime.GOARCH,
 	}
+
+	if runtime.GOOS == "linux" {
+		if err := sampleCPUUsage(&info); err == nil {
+			return info
+		}
+	}
+
+	// Fall back to platform tools when /proc/stat is unavailable
+	getCPUUsageFallback(&info)
+	return info
 }
 
-func getCPUUsage() float64 {
-	// Simplified for demo purposes - in production use gopsutil or similar
-	rand.Seed(time.Now().UnixNano())
-	baseValue := 30.0 + (rand.Float64() * 40.0)
-	variation := rand.Float64() * 10.0 - 5.0
-	
-	return baseValue + variation
+// sampleCPUUsage fills the usage breakdown from the delta between two /proc/stat readings
+func sampleCPUUsage(info *models.CPUInfo) error {
+	cpuStatMu.Lock()
+	defer cpuStatMu.Unlock()
+
+	current, currentCores, err := readCPUTimes()
+	if err != nil {
+		return err
+	}
+
+	// Inside a container, usage is also compared against the container's CPU limit
+	container := detectSelfContainer()
+	var containerSample containerCPUSample
+	hasContainerSample := false
+	if container != nil {
+		containerSample, hasContainerSample = container.readCPUSample()
+	}
+
+	// Without a previous reading, take a second one after a short interval
+	if !hasCPUSample {
+		lastCPUTimes = current
+		lastCoreTimes = currentCores
+		lastContainerCPU = containerSample
+		time.Sleep(cpuSampleInterval)
+
+		current, currentCores, err = readCPUTimes()
+		if err != nil {
+			return err
+		}
+		if container != nil {
+			containerSample, hasContainerSample = container.readCPUSample()
+		}
+	}
+
+	if hasContainerSample && !lastContainerCPU.readAt.IsZero() {
+		info.Container = containerCPUUsage(container, lastContainerCPU, containerSample)
+	}
+	lastContainerCPU = containerSample
+
+	total := cpuDelta(lastCPUTimes, current)
+	info.Usage = total.Usage
+	info.User = total.User
+	info.System = total.System
+	info.IOWait = total.IOWait
+	info.Steal = total.Steal
+	info.Idle = total.Idle
+
+	// Cores that went offline or came online since the last reading are skipped
+	coreIDs := make([]int, 0, len(currentCores))
+	for id := range currentCores {
+		if _, ok := lastCoreTimes[id]; ok {
+			coreIDs = append(coreIDs, id)
+		}
+	}
+	sort.Ints(coreIDs)
+
+	info.Cores = make([]models.CPUCoreInfo, 0, len(coreIDs))
+	for _, id := range coreIDs {
+		core := cpuDelta(lastCoreTimes[id], currentCores[id])
+		core.ID = id
+		info.Cores = append(info.Cores, core)
+	}
+
+	lastCPUTimes = current
+	lastCoreTimes = currentCores
+	hasCPUSample = true
+
+	return nil
+}
+
+// readCPUTimes reads the aggregate and per-core cpu lines from /proc/stat
+func readCPUTimes() (cpuTimes, map[int]cpuTimes, error) {
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return cpuTimes{}, nil, err
+	}
+	defer file.Close()
+
+	var total cpuTimes
+	foundTotal := false
+	cores := make(map[int]cpuTimes)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], "cpu") {
+			continue
+		}
+
+		if fields[0] == "cpu" {
+			total = parseCPUTimes(fields[1:])
+			foundTotal = true
+			continue
+		}
+
+		id, err := strconv.Atoi(strings.TrimPrefix(fields[0], "cpu"))
+		if err != nil {
+			continue
+		}
+		cores[id] = parseCPUTimes(fields[1:])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return cpuTimes{}, nil, err
+	}
+
+	if !foundTotal {
+		return cpuTimes{}, nil, fmt.Errorf("no cpu line found in /proc/stat")
+	}
+
+	return total, cores, nil
+}
+
+// parseCPUTimes converts the counter fields of a /proc/stat cpu line
+func parseCPUTimes(fields []string) cpuTimes {
+	values := make([]uint64, 8)
+	for i := 0; i < len(values) && i < len(fields); i++ {
+		values[i], _ = strconv.ParseUint(fields[i], 10, 64)
+	}
+
+	// Guest time is already accounted for in user and nice, so it is skipped
+	return cpuTimes{
+		User:    values[0],
+		Nice:    values[1],
+		System:  values[2],
+		Idle:    values[3],
+		IOWait:  values[4],
+		IRQ:     values[5],
+		SoftIRQ: values[6],
+		Steal:   values[7],
+	}
+}
+
+// total returns the sum of all counters
+func (t cpuTimes) total() uint64 {
+	return t.User + t.Nice + t.System + t.Idle + t.IOWait + t.IRQ + t.SoftIRQ + t.Steal
+}
+
+// cpuDelta computes utilization percentages between two readings
+func cpuDelta(prev, current cpuTimes) models.CPUCoreInfo {
+	var usage models.CPUCoreInfo
+
+	totalDelta := counterDelta(prev.total(), current.total())
+	if totalDelta == 0 {
+		return usage
+	}
+
+	percent := func(prevVal, curVal uint64) float64 {
+		return float64(counterDelta(prevVal, curVal)) / float64(totalDelta) * 100
+	}
+
+	// User includes niced time, system includes interrupt handling
+	usage.User = percent(prev.User+prev.Nice, current.User+current.Nice)
+	usage.System = percent(prev.System+prev.IRQ+prev.SoftIRQ, current.System+current.IRQ+current.SoftIRQ)
+	usage.IOWait = percent(prev.IOWait, current.IOWait)
+	usage.Steal = percent(prev.Steal, current.Steal)
+	usage.Idle = percent(prev.Idle, current.Idle)
+
+	// I/O wait is idle time from the CPU's point of view
+	usage.Usage = 100 - usage.Idle - usage.IOWait
+	if usage.Usage < 0 {
+		usage.Usage = 0
+	}
+
+	return usage
+}
+
+// counterDelta returns the increase of a counter, treating resets as zero
+func counterDelta(prev, current uint64) uint64 {
+	if current < prev {
+		return 0
+	}
+	return current - prev
+}
+
+// getCPUUsageFallback gets CPU usage from platform tools on non-Linux systems
+func getCPUUsageFallback(info *models.CPUInfo) {
+	switch runtime.GOOS {
+	case "darwin":
+		// Parse "CPU usage: 5.12% user, 10.25% sys, 84.62% idle"
+		cmd := exec.Command("top", "-l", "1", "-n", "0")
+		output, err := cmd.Output()
+		if err != nil {
+			return
+		}
+
+		usageRegex := regexp.MustCompile(`CPU usage:\s+([\d.]+)% user,\s+([\d.]+)% sys,\s+([\d.]+)% idle`)
+		matches := usageRegex.FindStringSubmatch(string(output))
+		if len(matches) == 4 {
+			info.User, _ = strconv.ParseFloat(matches[1], 64)
+			info.System, _ = strconv.ParseFloat(matches[2], 64)
+			info.Idle, _ = strconv.ParseFloat(matches[3], 64)
+			info.Usage = 100 - info.Idle
+		}
+	case "windows":
+		cmd := exec.Command("wmic", "cpu", "get", "loadpercentage")
+		output, err := cmd.Output()
+		if err != nil {
+			return
+		}
+
+		// Average the load across all processor sockets
+		total, count := 0.0, 0
+		for _, line := range strings.Split(string(output), "\n") {
+			load, err := strconv.ParseFloat(strings.TrimSpace(line), 64)
+			if err == nil {
+				total += load
+				count++
+			}
+		}
+
+		if count > 0 {
+			info.Usage = total / float64(count)
+			info.Idle = 100 - info.Usage
+		}
+	}
 }
 
 // getArchDescription formats architecture information for readability
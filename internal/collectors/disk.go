@@ -1,22 +1,52 @@
 package collectors
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
-	"math/rand"
 	"os"
-	"time"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
 
 	"github.com/tiwariParth/whosay/internal/models"
 	"github.com/tiwariParth/whosay/internal/ui"
 )
 
+// Filesystem types that don't represent real storage, hidden unless in verbose mode
+var pseudoFilesystems = map[string]bool{
+	"autofs":      true,
+	"binfmt_misc": true,
+	"bpf":         true,
+	"cgroup":      true,
+	"cgroup2":     true,
+	"configfs":    true,
+	"debugfs":     true,
+	"devpts":      true,
+	"devtmpfs":    true,
+	"efivarfs":    true,
+	"fusectl":     true,
+	"hugetlbfs":   true,
+	"mqueue":      true,
+	"nsfs":        true,
+	"overlay":     true,
+	"proc":        true,
+	"pstore":      true,
+	"rpc_pipefs":  true,
+	"securityfs":  true,
+	"squashfs":    true,
+	"sysfs":       true,
+	"tmpfs":       true,
+	"tracefs":     true,
+}
+
 // GetDiskInfo displays disk usage information
 func GetDiskInfo(opts models.Options) {
-	info := collectDiskInfo()
+	disks := collectDiskInfo(opts)
 
 	if opts.JSONOutput {
-		jsonData, _ := json.MarshalIndent(info, "", "  ")
+		jsonData, _ := json.MarshalIndent(disks, "", "  ")
 		fmt.Println(string(jsonData))
 		return
 	}
@@ -28,36 +58,51 @@ func GetDiskInfo(opts models.Options) {
 
 // GetDiskInfoSections returns formatted disk information sections
 func GetDiskInfoSections(opts models.Options) map[string][][]string {
-	info := collectDiskInfo()
-
-	// Process path for display (shorten if needed)
-	displayPath := info.Path
-	if len(displayPath) > 30 {
-		displayPath = "..." + displayPath[len(displayPath)-27:]
-	}
-	
-	// Calculate sizes in more readable units with consistent formatting
-	totalGB := float64(info.Total) / 1024 / 1024 / 1024
-	freeGB := float64(info.Free) / 1024 / 1024 / 1024
-	usedGB := totalGB - freeGB
+	disks := collectDiskInfo(opts)
 	
-	// Create compact data structure with improved formatting - use fixed-width values
-	diskData := [][]string{
-		{"Path", displayPath},
-		{"Total", fmt.Sprintf("%.1f GB", totalGB)},
-		{"Used", fmt.Sprintf("%.1f GB", usedGB)},
-		{"Free", fmt.Sprintf("%.1f GB", freeGB)},
+	if len(disks) == 0 {
+		return map[string][][]string{
+			"Disk": {
+				{"Status", "No filesystems found"},
+			},
+		}
 	}
 	
-	// Add usage bar with consistent width
-	barWidth := 20 // Simplified, always use same width for consistency
-	if opts.CompactMode {
-		barWidth = 15 // Even smaller for compact mode
+	header := []string{"Mount", "Type", "Size", "Used", "Avail", "Use%", "Inodes"}
+	if opts.VerboseOutput {
+		header = append(header, "Device", "Options")
 	}
+	diskData := [][]string{header}
 	
-	diskData = append(diskData, []string{
-		"Usage", ui.PrintCompactUsageBar("", info.UsagePerc, barWidth),
-	})
+	for _, disk := range disks {
+		// Shorten long mount points, keeping the end which is usually more meaningful
+		mount := disk.Path
+		if len(mount) > 30 && !opts.VerboseOutput {
+			mount = "..." + mount[len(mount)-27:]
+		}
+		
+		// Some filesystems (e.g. btrfs, vfat) don't report inodes
+		inodes := "-"
+		if disk.InodesTotal > 0 {
+			inodes = ui.FormatPercent(disk.InodesUsagePerc)
+		}
+		
+		row := []string{
+			mount,
+			disk.FSType,
+			formatBytes(disk.Total),
+			formatBytes(disk.Used),
+			formatBytes(disk.Free),
+			ui.FormatPercent(disk.UsagePerc),
+			inodes,
+		}
+		
+		if opts.VerboseOutput {
+			row = append(row, disk.Device, disk.Options)
+		}
+		
+		diskData = append(diskData, row)
+	}
 	
 	// Return data for unified display
 	return map[string][][]string{
@@ -65,28 +110,215 @@ func GetDiskInfoSections(opts models.Options) map[string][][]string {
 	}
 }
 
-// collectDiskInfo gathers disk usage information
-func collectDiskInfo() models.DiskInfo {
-	// Get current directory for demonstration
-	path, err := os.Getwd()
+// collectDiskInfo gathers usage information for every mounted filesystem
+func collectDiskInfo(opts models.Options) []models.DiskInfo {
+	switch runtime.GOOS {
+	case "linux":
+		return getLinuxDisks(opts.VerboseOutput)
+	case "darwin":
+		return getDarwinDisks()
+	case "windows":
+		return getWindowsDisks()
+	}
+
+	return []models.DiskInfo{}
+}
+
+// getLinuxDisks enumerates mounts from /proc/self/mountinfo and queries each with statfs
+func getLinuxDisks(includePseudo bool) []models.DiskInfo {
+	mounts, err := readMountInfo()
 	if err != nil {
-		path = "/"
+		return []models.DiskInfo{}
 	}
-	
-	// Add some randomness to make watch mode more interesting
-	rand.Seed(time.Now().UnixNano())
-	
-	// Base usage percentage
-	usagePerc := 60.0
-	
-	// Vary by +/- 3%
-	usagePerc += (rand.Float64() * 6.0) - 3.0
-	
-	// This is a simplified implementation
-	return models.DiskInfo{
-		Path:       path,
-		Total:      1024 * 1024 * 1024 * 100, // Simulate 100GB
-		Free:       uint64(float64(1024 * 1024 * 1024 * 100) * (1.0 - usagePerc/100.0)),
-		UsagePerc:  usagePerc,
+
+	result := []models.DiskInfo{}
+	seenDevices := make(map[string]bool)
+
+	for _, mount := range mounts {
+		if pseudoFilesystems[mount.FSType] && !includePseudo {
+			continue
+		}
+
+		// Bind mounts show the same filesystem several times, keep the first one that
+		// can be read. A hanging or inaccessible mount doesn't hide the others.
+		if !includePseudo && seenDevices[mount.deviceID] {
+			continue
+		}
+
+		disk := mount.DiskInfo
+		if err := statFilesystem(&disk); err != nil {
+			continue
+		}
+		seenDevices[mount.deviceID] = true
+
+		// Filesystems without any blocks are virtual as well
+		if disk.Total == 0 && !includePseudo {
+			continue
+		}
+
+		result = append(result, disk)
+	}
+
+	return result
+}
+
+// mountEntry is a parsed line of /proc/self/mountinfo
+type mountEntry struct {
+	models.DiskInfo
+	deviceID string
+}
+
+// readMountInfo parses /proc/self/mountinfo
+func readMountInfo() ([]mountEntry, error) {
+	file, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	mounts := []mountEntry{}
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// 36 35 98:0 /mnt1 /mnt2 rw,noatime master:1 - ext3 /dev/root rw,errors=continue
+		fields := strings.Fields(scanner.Text())
+
+		// Optional fields end with a single hyphen separator
+		sep := -1
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				sep = i
+				break
+			}
+		}
+		if sep < 0 || len(fields) < sep+3 {
+			continue
+		}
+
+		mounts = append(mounts, mountEntry{
+			DiskInfo: models.DiskInfo{
+				Path:    unescapeMountPath(fields[4]),
+				Options: fields[5],
+				FSType:  fields[sep+1],
+				Device:  unescapeMountPath(fields[sep+2]),
+			},
+			deviceID: fields[2],
+		})
+	}
+
+	return mounts, scanner.Err()
+}
+
+// unescapeMountPath decodes the octal escapes used for spaces and tabs in mount paths
+func unescapeMountPath(path string) string {
+	if !strings.Contains(path, "\\") {
+		return path
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(path); i++ {
+		if path[i] == '\\' && i+3 < len(path) {
+			if value, err := strconv.ParseUint(path[i+1:i+4], 8, 8); err == nil {
+				sb.WriteByte(byte(value))
+				i += 3
+				continue
+			}
+		}
+		sb.WriteByte(path[i])
+	}
+
+	return sb.String()
+}
+
+// setDiskUsage fills the derived usage fields from raw block counts
+func setDiskUsage(disk *models.DiskInfo) {
+	// Like df, base the percentage on the space available to unprivileged users
+	if disk.Used+disk.Free > 0 {
+		disk.UsagePerc = float64(disk.Used) / float64(disk.Used+disk.Free) * 100
+	}
+
+	if disk.InodesTotal > 0 {
+		disk.InodesUsed = disk.InodesTotal - disk.InodesFree
+		disk.InodesUsagePerc = float64(disk.InodesUsed) / float64(disk.InodesTotal) * 100
+	}
+}
+
+// getDarwinDisks gets filesystem usage on macOS by parsing df
+func getDarwinDisks() []models.DiskInfo {
+	result := []models.DiskInfo{}
+
+	output, err := exec.Command("df", "-kP").Output()
+	if err != nil {
+		return result
+	}
+
+	lines := strings.Split(string(output), "\n")
+	if len(lines) <= 1 {
+		return result
 	}
+
+	// Filesystem 1024-blocks Used Available Capacity Mounted on
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		if len(fields) < 6 || !strings.HasPrefix(fields[0], "/dev/") {
+			continue
+		}
+
+		total, _ := strconv.ParseUint(fields[1], 10, 64)
+		used, _ := strconv.ParseUint(fields[2], 10, 64)
+		free, _ := strconv.ParseUint(fields[3], 10, 64)
+
+		disk := models.DiskInfo{
+			Path:   strings.Join(fields[5:], " "),
+			Device: fields[0],
+			FSType: "apfs",
+			Total:  total * 1024,
+			Used:   used * 1024,
+			Free:   free * 1024,
+		}
+		setDiskUsage(&disk)
+
+		result = append(result, disk)
+	}
+
+	return result
+}
+
+// getWindowsDisks gets logical drive usage on Windows using wmic
+func getWindowsDisks() []models.DiskInfo {
+	result := []models.DiskInfo{}
+
+	cmd := exec.Command("wmic", "logicaldisk", "get", "Caption,FileSystem,FreeSpace,Size", "/format:csv")
+	output, err := cmd.Output()
+	if err != nil {
+		return result
+	}
+
+	// CSV columns are Node,Caption,FileSystem,FreeSpace,Size
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Split(strings.TrimSpace(line), ",")
+		if len(parts) < 5 {
+			continue
+		}
+
+		free, errFree := strconv.ParseUint(parts[3], 10, 64)
+		total, errTotal := strconv.ParseUint(parts[4], 10, 64)
+		if errFree != nil || errTotal != nil || total == 0 {
+			continue
+		}
+
+		disk := models.DiskInfo{
+			Path:   parts[1],
+			Device: parts[1],
+			FSType: parts[2],
+			Total:  total,
+			Free:   free,
+			Used:   total - free,
+		}
+		setDiskUsage(&disk)
+
+		result = append(result, disk)
+	}
+
+	return result
 }
@@ -0,0 +1,281 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Size of a sector as reported by /proc/diskstats, regardless of the device
+const diskSectorSize = 512
+
+// Interval used for the second reading when no previous disk I/O sample exists
+const diskIOSampleInterval = 250 * time.Millisecond
+
+// diskStats holds the cumulative counters of a /proc/diskstats line
+type diskStats struct {
+	ReadsCompleted  uint64
+	SectorsRead     uint64
+	ReadTimeMs      uint64
+	WritesCompleted uint64
+	SectorsWritten  uint64
+	WriteTimeMs     uint64
+	IOTimeMs        uint64
+}
+
+// Disk I/O data store, kept across watch mode ticks
+var (
+	lastDiskStats  map[string]diskStats
+	lastDiskReadAt time.Time
+	diskIOHistory  map[string][]models.DiskIOInfo
+	diskIOMu       sync.Mutex
+)
+
+func init() {
+	diskIOHistory = make(map[string][]models.DiskIOInfo)
+}
+
+// GetDiskIOInfo displays disk I/O throughput and latency
+func GetDiskIOInfo(opts models.Options) {
+	info := GetDiskIOUsage(opts.VerboseOutput)
+
+	if opts.JSONOutput {
+		jsonData, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing disk I/O data: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	// Display disk I/O info in compact view
+	sections := GetDiskIOInfoSections(opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetDiskIOInfoSections formats disk I/O information for the compact display
+func GetDiskIOInfoSections(opts models.Options) map[string][][]string {
+	usage := GetDiskIOUsage(opts.VerboseOutput)
+	
+	if len(usage) == 0 {
+		return map[string][][]string{
+			"Disk I/O": {
+				{"Status", "No block device statistics available"},
+			},
+		}
+	}
+	
+	ioData := [][]string{
+		{"Device", "Read", "Write", "r/s", "w/s", "Await", "Util"},
+	}
+	
+	for _, dev := range usage {
+		ioData = append(ioData, []string{
+			dev.Device,
+			fmt.Sprintf("%.1f MB/s", dev.ReadRate),
+			fmt.Sprintf("%.1f MB/s", dev.WriteRate),
+			fmt.Sprintf("%.0f", dev.ReadIOPS),
+			fmt.Sprintf("%.0f", dev.WriteIOPS),
+			fmt.Sprintf("%.1f ms", dev.AvgAwait),
+			ui.FormatPercent(dev.Utilization),
+		})
+	}
+	
+	result := map[string][][]string{
+		"Disk I/O": ioData,
+	}
+	
+	// History sparklines only make sense once watch mode has collected a few samples
+	if opts.InWatchMode {
+		historyData := [][]string{}
+		
+		for _, dev := range usage {
+			readHistory, writeHistory := getDiskIOHistory(dev.Device)
+			if len(readHistory) < 2 {
+				continue
+			}
+			
+			historyData = append(historyData, []string{
+				dev.Device,
+				fmt.Sprintf("R %s  W %s",
+					ui.SuccessColor(ui.RenderSparkline(readHistory, 30)),
+					ui.WarningColor(ui.RenderSparkline(writeHistory, 30))),
+			})
+		}
+		
+		if len(historyData) > 0 {
+			result["Disk I/O History"] = historyData
+		}
+	}
+	
+	return result
+}
+
+// GetDiskIOUsage returns per-device I/O rates since the previous reading
+func GetDiskIOUsage(includeAll bool) []models.DiskIOInfo {
+	diskIOMu.Lock()
+	defer diskIOMu.Unlock()
+	
+	current, err := readDiskStats()
+	if err != nil {
+		return []models.DiskIOInfo{}
+	}
+	
+	// Without a previous reading, take a second one after a short interval
+	if lastDiskStats == nil {
+		lastDiskStats = current
+		lastDiskReadAt = time.Now()
+		time.Sleep(diskIOSampleInterval)
+		
+		current, err = readDiskStats()
+		if err != nil {
+			return []models.DiskIOInfo{}
+		}
+	}
+	
+	now := time.Now()
+	elapsed := now.Sub(lastDiskReadAt).Seconds()
+	if elapsed < 0.1 {
+		elapsed = 0.1 // Prevent division by zero or unrealistically small time periods
+	}
+	
+	result := make([]models.DiskIOInfo, 0, len(current))
+	
+	for device, stats := range current {
+		if !includeAll && !isWholeDisk(device) {
+			continue
+		}
+		
+		info := models.DiskIOInfo{
+			Device:     device,
+			ReadBytes:  stats.SectorsRead * diskSectorSize,
+			WriteBytes: stats.SectorsWritten * diskSectorSize,
+			Timestamp:  now,
+		}
+		
+		if prev, ok := lastDiskStats[device]; ok {
+			reads := counterDelta(prev.ReadsCompleted, stats.ReadsCompleted)
+			writes := counterDelta(prev.WritesCompleted, stats.WritesCompleted)
+			
+			info.ReadRate = float64(counterDelta(prev.SectorsRead, stats.SectorsRead)*diskSectorSize) / elapsed / 1024 / 1024
+			info.WriteRate = float64(counterDelta(prev.SectorsWritten, stats.SectorsWritten)*diskSectorSize) / elapsed / 1024 / 1024
+			info.ReadIOPS = float64(reads) / elapsed
+			info.WriteIOPS = float64(writes) / elapsed
+			
+			// Average time each request spent queued and being serviced
+			if reads+writes > 0 {
+				waitMs := counterDelta(prev.ReadTimeMs, stats.ReadTimeMs) + counterDelta(prev.WriteTimeMs, stats.WriteTimeMs)
+				info.AvgAwait = float64(waitMs) / float64(reads+writes)
+			}
+			
+			// Share of wall time the device had at least one request in flight
+			info.Utilization = float64(counterDelta(prev.IOTimeMs, stats.IOTimeMs)) / (elapsed * 1000) * 100
+			if info.Utilization > 100 {
+				info.Utilization = 100
+			}
+			
+			updateDiskIOHistory(device, info.ReadRate, info.WriteRate)
+		}
+		
+		result = append(result, info)
+	}
+	
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Device < result[j].Device
+	})
+	
+	// Update stored readings and time
+	lastDiskStats = current
+	lastDiskReadAt = now
+	
+	return result
+}
+
+// readDiskStats reads block device statistics from /proc/diskstats on Linux
+func readDiskStats() (map[string]diskStats, error) {
+	result := make(map[string]diskStats)
+	
+	data, err := os.ReadFile("/proc/diskstats")
+	if err != nil {
+		return result, err
+	}
+	
+	for _, line := range strings.Split(string(data), "\n") {
+		// major minor name reads merged sectors ms writes merged sectors ms in_flight io_ms weighted_ms ...
+		fields := strings.Fields(line)
+		if len(fields) < 14 {
+			continue
+		}
+		
+		parse := func(idx int) uint64 {
+			value, _ := strconv.ParseUint(fields[idx], 10, 64)
+			return value
+		}
+		
+		result[fields[2]] = diskStats{
+			ReadsCompleted:  parse(3),
+			SectorsRead:     parse(5),
+			ReadTimeMs:      parse(6),
+			WritesCompleted: parse(7),
+			SectorsWritten:  parse(9),
+			WriteTimeMs:     parse(10),
+			IOTimeMs:        parse(12),
+		}
+	}
+	
+	return result, nil
+}
+
+// isWholeDisk reports whether a device is a real disk rather than a partition or loop/ram device
+func isWholeDisk(device string) bool {
+	if strings.HasPrefix(device, "loop") || strings.HasPrefix(device, "ram") {
+		return false
+	}
+	
+	// Partitions don't have their own entry in /sys/block
+	_, err := os.Stat("/sys/block/" + device)
+	return err == nil
+}
+
+// updateDiskIOHistory adds new throughput readings to the history
+func updateDiskIOHistory(device string, readRate, writeRate float64) {
+	history := diskIOHistory[device]
+	history = append(history, models.DiskIOInfo{
+		Device:    device,
+		ReadRate:  readRate,
+		WriteRate: writeRate,
+		Timestamp: time.Now(),
+	})
+	
+	// Keep the same window as the network traffic history
+	if len(history) > historyLength {
+		history = history[1:]
+	}
+	diskIOHistory[device] = history
+}
+
+// getDiskIOHistory returns historical read and write throughput for a device
+func getDiskIOHistory(device string) ([]float64, []float64) {
+	diskIOMu.Lock()
+	defer diskIOMu.Unlock()
+	
+	history := diskIOHistory[device]
+	
+	readHistory := make([]float64, len(history))
+	writeHistory := make([]float64, len(history))
+	for i, entry := range history {
+		readHistory[i] = entry.ReadRate
+		writeHistory[i] = entry.WriteRate
+	}
+	
+	return readHistory, writeHistory
+}
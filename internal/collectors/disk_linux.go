@@ -0,0 +1,76 @@
+package collectors
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// How long to wait for statfs before giving up on a mount (e.g. a hung NFS share)
+const statfsTimeout = 2 * time.Second
+
+// Mounts with a statfs call still running. A call stuck on a hung mount can't be
+// cancelled, so the mount is skipped until it returns rather than leaking a goroutine
+// on every watch tick.
+var (
+	pendingStatfs   = make(map[string]bool)
+	pendingStatfsMu sync.Mutex
+)
+
+// statFilesystem fills capacity and inode usage for a mount point using statfs
+func statFilesystem(disk *models.DiskInfo) error {
+	type statfsResult struct {
+		stat syscall.Statfs_t
+		err  error
+	}
+
+	path := disk.Path
+	pendingStatfsMu.Lock()
+	if pendingStatfs[path] {
+		pendingStatfsMu.Unlock()
+		return fmt.Errorf("statfs on %s is still hanging", path)
+	}
+	pendingStatfs[path] = true
+	pendingStatfsMu.Unlock()
+
+	done := make(chan statfsResult, 1)
+	go func() {
+		var res statfsResult
+		res.err = syscall.Statfs(path, &res.stat)
+
+		pendingStatfsMu.Lock()
+		delete(pendingStatfs, path)
+		pendingStatfsMu.Unlock()
+
+		done <- res
+	}()
+
+	var stat syscall.Statfs_t
+	select {
+	case res := <-done:
+		if res.err != nil {
+			return res.err
+		}
+		stat = res.stat
+	case <-time.After(statfsTimeout):
+		return fmt.Errorf("statfs on %s timed out", path)
+	}
+
+	blockSize := uint64(stat.Frsize)
+	if blockSize == 0 {
+		blockSize = uint64(stat.Bsize)
+	}
+
+	disk.Total = stat.Blocks * blockSize
+	disk.Free = stat.Bavail * blockSize
+	disk.Used = (stat.Blocks - stat.Bfree) * blockSize
+	disk.InodesTotal = stat.Files
+	disk.InodesFree = stat.Ffree
+
+	setDiskUsage(disk)
+
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package collectors
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// statFilesystem is only implemented on Linux; other platforms use df or wmic
+func statFilesystem(disk *models.DiskInfo) error {
+	return fmt.Errorf("statfs is not supported on %s", runtime.GOOS)
+}
@@ -1,27 +1,31 @@
 package collectors
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os/exec"
-	"strconv"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/tiwariParth/whosay/internal/docker"
 	"github.com/tiwariParth/whosay/internal/models"
 	"github.com/tiwariParth/whosay/internal/ui"
 )
 
-// GetDockerInfo displays information about running Docker containers
+// GetDockerInfo displays information about running containers of the detected runtime
 func GetDockerInfo(opts models.Options) {
-	containers, err := GetDockerContainers()
+	containers, projects, err := GetComposeProjects(opts.Docker)
 	if err != nil {
 		// Check if it's just docker not being available
 		if isDockerNotInstalled(err) {
 			if opts.JSONOutput {
 				fmt.Println("[]")
 			} else {
-				fmt.Println("Docker doesn't appear to be installed or isn't running.")
-				fmt.Println("Install Docker or start the Docker service to see container information.")
+				fmt.Println("No container runtime appears to be installed or running.")
+				fmt.Println("Install or start Docker, Podman (podman.socket) or containerd with crictl to see container information.")
 			}
 			return
 		}
@@ -32,6 +36,8 @@ func GetDockerInfo(opts models.Options) {
 	}
 
 	if opts.JSONOutput {
+		// Always the container array, scoped to the project with -docker-project, so
+		// scripts parse one shape; each container carries its project and service
 		jsonData, err := json.MarshalIndent(containers, "", "  ")
 		if err != nil {
 			fmt.Printf("Error serializing container data: %v\n", err)
@@ -42,24 +48,50 @@ func GetDockerInfo(opts models.Options) {
 	}
 
 	// Format and display containers in compact view
-	sections := GetDockerInfoSections(containers, opts)
+	sections := GetDockerInfoSections(containers, projects, opts)
 	ui.CompactDisplay(sections)
 }
 
-// GetDockerInfoSections formats container information for the compact display
-func GetDockerInfoSections(containers []models.ContainerInfo, opts models.Options) map[string][][]string {
+// GetDockerInfoSections formats container information for the compact display, with
+// containers of the same Compose project and service next to each other
+func GetDockerInfoSections(containers []models.ContainerInfo, projects []models.ComposeProject, opts models.Options) map[string][][]string {
 	// Create the main docker section
 	dockerData := [][]string{
-		{"Containers", fmt.Sprintf("%d running", len(containers))},
+		{"Containers", summarizeContainerStates(containers)},
+	}
+
+	if runtime := containerRuntimeName(); runtime != "" && runtime != RuntimeDocker {
+		dockerData = append(dockerData, []string{"Runtime", runtime})
+	}
+
+	if opts.Docker.Project != "" {
+		dockerData = append(dockerData, []string{"Project", opts.Docker.Project})
+	} else if len(projects) > 0 {
+		dockerData = append(dockerData, []string{"Projects", fmt.Sprintf("%d", len(projects))})
 	}
 
 	if len(containers) == 0 {
-		dockerData = append(dockerData, []string{"Status", "No containers running"})
+		status := "No containers running"
+		if opts.Docker.All {
+			status = "No containers"
+		}
+		if opts.Docker.Project != "" {
+			status = fmt.Sprintf("No containers in project %s", opts.Docker.Project)
+		}
+		dockerData = append(dockerData, []string{"Status", status})
 	}
 
 	// Create container sections
 	containerSections := [][]string{}
 
+	containers = append([]models.ContainerInfo(nil), containers...)
+	sort.SliceStable(containers, func(i, j int) bool {
+		if containers[i].Project != containers[j].Project {
+			return containers[i].Project < containers[j].Project
+		}
+		return containers[i].Service < containers[j].Service
+	})
+
 	// Create a container table
 	for _, container := range containers {
 		// Format name (remove leading slash if present)
@@ -67,6 +99,7 @@ func GetDockerInfoSections(containers []models.ContainerInfo, opts models.Option
 		if strings.HasPrefix(name, "/") {
 			name = name[1:]
 		}
+		stateColor := containerStateColor(container)
 
 		// Calculate memory in MB
 		memoryMB := float64(container.MemoryUsage) / 1024 / 1024
@@ -88,14 +121,63 @@ func GetDockerInfoSections(containers []models.ContainerInfo, opts models.Option
 		}
 
 		containerSections = append(containerSections, []string{
-			name,
-			fmt.Sprintf("%-25s %s", image, container.Status),
+			stateColor(name),
+			stateColor(fmt.Sprintf("%-25s %s", image, container.Status)),
 		})
 		
+		if container.Project != "" {
+			project := container.Project
+			if container.Service != "" {
+				project += " / " + container.Service
+			}
+			containerSections = append(containerSections, []string{
+				"Project",
+				project,
+			})
+		}
+		
+		if container.PodUID != "" {
+			containerSections = append(containerSections, []string{
+				"Pod",
+				container.PodUID,
+			})
+		}
+		
+		if container.State != "running" {
+			containerSections = append(containerSections, []string{
+				"State",
+				stateColor(describeContainerState(container)),
+			})
+		}
+		
+		if container.Health != "" {
+			containerSections = append(containerSections, []string{
+				"Health",
+				healthColor(container.Health),
+			})
+		}
+		
+		if container.RestartCount > 0 {
+			containerSections = append(containerSections, []string{
+				"Restarts",
+				ui.WarningColor(fmt.Sprintf("%d", container.RestartCount)),
+			})
+		}
+		
+		// Stopped containers have no resource usage or addresses
+		if container.State != "running" {
+			containerSections = append(containerSections, []string{"", ""})
+			continue
+		}
+		
 		// Add detailed info per container
+		cpu := fmt.Sprintf("%.1f%%", container.CPUPercent)
+		if container.Cgroup != nil && container.Cgroup.CPULimit > 0 {
+			cpu += fmt.Sprintf(" (limit %.2f cores)", container.Cgroup.CPULimit)
+		}
 		containerSections = append(containerSections, []string{
 			"CPU",
-			fmt.Sprintf("%.1f%%", container.CPUPercent),
+			cpu,
 		})
 		
 		// Add memory info
@@ -111,6 +193,10 @@ func GetDockerInfoSections(containers []models.ContainerInfo, opts models.Option
 			})
 		}
 		
+		if container.Cgroup != nil {
+			containerSections = append(containerSections, getCgroupRows(*container.Cgroup)...)
+		}
+		
 		// Add IP and ports if available
 		if container.IPAddress != "" {
 			containerSections = append(containerSections, []string{
@@ -150,140 +236,355 @@ func GetDockerInfoSections(containers []models.ContainerInfo, opts models.Option
 		result["Containers"] = containerSections
 	}
 
+	for name, section := range GetComposeProjectSections(projects, opts) {
+		result[name] = section
+	}
+
 	return result
 }
 
-// GetDockerContainers returns information about running Docker containers
-func GetDockerContainers() ([]models.ContainerInfo, error) {
-	result := []models.ContainerInfo{}
+// Limit on concurrent inspect and stats requests sent to the daemon
+const dockerFetchConcurrency = 8
+
+// How long to wait for the daemon when collecting container information.
+// Stats requests take about a second since the daemon samples CPU usage twice.
+const dockerRequestTimeout = 15 * time.Second
+
+// getCgroupRows formats the throttling, OOM kills, PIDs and block I/O of a container cgroup
+func getCgroupRows(stats models.CgroupStats) [][]string {
+	rows := [][]string{}
+
+	// Only cgroups with a CPU quota are throttled
+	if stats.Periods > 0 {
+		throttled := fmt.Sprintf("%.1f%% of periods, %s total", stats.ThrottledPercent,
+			(time.Duration(stats.ThrottledUsec) * time.Microsecond).Round(time.Millisecond))
+		if stats.ThrottledPercent > 0 {
+			throttled = ui.WarningColor(throttled)
+		}
+		rows = append(rows, []string{"Throttled", throttled})
+	}
+
+	if stats.OOMKills > 0 {
+		rows = append(rows, []string{"OOM Kills", ui.DangerColor(fmt.Sprintf("%d", stats.OOMKills))})
+	}
+
+	pids := fmt.Sprintf("%d", stats.PIDs)
+	if stats.PIDsMax > 0 {
+		pids += fmt.Sprintf(" / %d", stats.PIDsMax)
+	}
+	rows = append(rows, []string{"PIDs", pids})
+
+	if stats.IOReadBytes > 0 || stats.IOWriteBytes > 0 {
+		rows = append(rows, []string{"Block I/O", fmt.Sprintf("%s read, %s written", formatBytes(stats.IOReadBytes), formatBytes(stats.IOWriteBytes))})
+	}
+
+	return rows
+}
+
+// summarizeContainerStates counts containers per state, running first
+func summarizeContainerStates(containers []models.ContainerInfo) string {
+	if len(containers) == 0 {
+		return "0 running"
+	}
+
+	counts := make(map[string]int)
+	states := []string{}
+	for _, container := range containers {
+		if counts[container.State] == 0 {
+			states = append(states, container.State)
+		}
+		counts[container.State]++
+	}
+
+	sort.SliceStable(states, func(i, j int) bool {
+		return states[i] == "running" && states[j] != "running"
+	})
+
+	parts := make([]string, 0, len(states))
+	for _, state := range states {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeContainerState explains why a container is not running
+func describeContainerState(container models.ContainerInfo) string {
+	parts := []string{container.State}
+
+	if container.State == "exited" || container.State == "dead" || container.State == "restarting" {
+		parts = append(parts, fmt.Sprintf("exit code %d", container.ExitCode))
+	}
+	if container.OOMKilled {
+		parts = append(parts, "OOM killed")
+	}
+	if !container.FinishedAt.IsZero() {
+		parts = append(parts, "finished "+formatSince(container.FinishedAt))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// containerStateColor picks the row color for a container state
+func containerStateColor(container models.ContainerInfo) func(a ...interface{}) string {
+	switch {
+	case container.State == "running" && container.Health == "unhealthy":
+		return ui.WarningColor
+	case container.State == "running":
+		return ui.SuccessColor
+	case container.State == "restarting" || container.State == "paused":
+		return ui.WarningColor
+	case container.OOMKilled || container.State == "dead":
+		return ui.DangerColor
+	case container.State == "exited" && container.ExitCode != 0:
+		return ui.DangerColor
+	default:
+		return ui.DimColor
+	}
+}
 
-	// Check if docker is installed
-	if _, err := exec.LookPath("docker"); err != nil {
-		return result, fmt.Errorf("docker command not found: %w", err)
+// healthColor colors a health check status
+func healthColor(health string) string {
+	switch health {
+	case "healthy":
+		return ui.SuccessColor(health)
+	case "unhealthy":
+		return ui.DangerColor(health)
+	default:
+		return ui.WarningColor(health)
 	}
+}
+
+// formatSince describes how long ago a time was, e.g. "5m ago"
+func formatSince(t time.Time) string {
+	elapsed := time.Since(t)
+	switch {
+	case elapsed < time.Minute:
+		return fmt.Sprintf("%ds ago", int(elapsed.Seconds()))
+	case elapsed < time.Hour:
+		return fmt.Sprintf("%dm ago", int(elapsed.Minutes()))
+	case elapsed < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(elapsed.Hours()))
+	}
+	return fmt.Sprintf("%dd ago", int(elapsed.Hours()/24))
+}
+
+// GetDockerContainers returns information about running containers of the detected
+// runtime (Docker, Podman or containerd), or about every container including stopped
+// ones when display.All is set
+func GetDockerContainers(display models.DockerDisplay) ([]models.ContainerInfo, error) {
+	containers, _, err := collectRuntimeContainers(display, true)
+	return containers, err
+}
 
-	// Get list of containers
-	cmd := exec.Command("docker", "ps", "--format", "{{.ID}}|{{.Names}}|{{.Image}}|{{.Status}}|{{.Command}}|{{.CreatedAt}}")
-	output, err := cmd.Output()
+// collectContainers lists containers through the Engine API and fetches inspect data
+// concurrently. Stopped containers are always listed
+// to count the replicas of Compose services, which are returned keyed by composeServiceKey.
+func collectContainers(ctx context.Context, client *docker.Client, display models.DockerDisplay) ([]models.ContainerInfo, map[string]int, error) {
+	options := docker.ListOptions{All: true}
+	if display.Project != "" {
+		options.Filters = map[string][]string{"label": {composeProjectLabel + "=" + display.Project}}
+	}
+
+	all, err := client.ListContainers(ctx, options)
 	if err != nil {
-		return result, fmt.Errorf("failed to run docker ps: %w", err)
+		return []models.ContainerInfo{}, nil, fmt.Errorf("failed to list containers: %w", err)
 	}
 
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if line == "" {
-			continue
+	// Without -docker-all only running containers are shown, like `docker ps`
+	list := all
+	if !display.All {
+		list = []docker.Container{}
+		for _, summary := range all {
+			if isActiveContainerState(summary.State) {
+				list = append(list, summary)
+			}
 		}
+	}
+
+	result := make([]models.ContainerInfo, len(list))
+	semaphore := make(chan struct{}, dockerFetchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, summary := range list {
+		result[i] = containerFromSummary(summary)
+
+		wg.Add(1)
+		go func(container *models.ContainerInfo) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
 
-		parts := strings.Split(line, "|")
-		if len(parts) < 5 {
+			fillContainerDetails(ctx, client, container)
+		}(&result[i])
+	}
+
+	wg.Wait()
+	return result, composeReplicas(all), nil
+}
+
+// isActiveContainerState reports whether the daemon lists containers in the state
+// without all=1: running ones, including paused and restarting
+func isActiveContainerState(state string) bool {
+	return state == "running" || state == "paused" || state == "restarting"
+}
+
+// containerFromSummary converts a container list entry
+func containerFromSummary(summary docker.Container) models.ContainerInfo {
+	container := models.ContainerInfo{
+		ID:      shortContainerID(summary.ID),
+		Image:   summary.Image,
+		Status:  summary.Status,
+		State:   summary.State,
+		Command: summary.Command,
+	}
+
+	if len(summary.Names) > 0 {
+		container.Name = strings.TrimPrefix(summary.Names[0], "/")
+	}
+	if summary.Created > 0 {
+		container.CreatedAt = time.Unix(summary.Created, 0)
+	}
+	container.Project = summary.Labels[composeProjectLabel]
+	if !strings.EqualFold(summary.Labels[composeOneOffLabel], "true") {
+		container.Service = summary.Labels[composeServiceLabel]
+	}
+	if container.State == "" {
+		container.State = "unknown"
+	}
+
+	// Only published ports, formatted like `docker port`
+	for _, port := range summary.Ports {
+		if port.PublicPort == 0 {
 			continue
 		}
-
-		// Parse container info
-		container := models.ContainerInfo{
-			ID:      parts[0],
-			Name:    parts[1],
-			Image:   parts[2],
-			Status:  parts[3],
-			Command: parts[4],
+		ip := port.IP
+		if ip == "" {
+			ip = "0.0.0.0"
 		}
+		container.Ports = append(container.Ports, fmt.Sprintf("%d/%s -> %s:%d", port.PrivatePort, port.Type, ip, port.PublicPort))
+	}
 
-		// Set state based on status
-		if strings.HasPrefix(container.Status, "Up") {
-			container.State = "running"
-		} else if strings.HasPrefix(container.Status, "Exited") {
-			container.State = "exited"
-		} else if strings.HasPrefix(container.Status, "Created") {
-			container.State = "created"
-		} else {
-			container.State = "unknown"
-		}
+	return container
+}
 
-		// If the container is running, get more detailed info
-		if container.State == "running" {
-			// Get container stats
-			statCmd := exec.Command("docker", "stats", "--no-stream", "--format", 
-			                        "{{.CPUPerc}}|{{.MemUsage}}|{{.MemPerc}}", container.ID)
-			statOutput, err := statCmd.Output()
-			if err == nil {
-				statParts := strings.Split(strings.TrimSpace(string(statOutput)), "|")
-				if len(statParts) >= 3 {
-					// Parse CPU percentage
-					cpuStr := strings.TrimSuffix(statParts[0], "%")
-					cpu, err := strconv.ParseFloat(cpuStr, 64)
-					if err == nil {
-						container.CPUPercent = cpu
-					}
-
-					// Parse memory usage
-					memParts := strings.Fields(statParts[1])
-					if len(memParts) >= 3 {
-						mem, unit := memParts[0], memParts[1]
-						memVal, err := strconv.ParseFloat(mem, 64)
-						if err == nil {
-							// Convert to bytes based on unit
-							switch unit {
-							case "KiB", "KB":
-								container.MemoryUsage = uint64(memVal * 1024)
-							case "MiB", "MB":
-								container.MemoryUsage = uint64(memVal * 1024 * 1024)
-							case "GiB", "GB":
-								container.MemoryUsage = uint64(memVal * 1024 * 1024 * 1024)
-							}
-						}
-
-						// Parse memory limit if available
-						if len(memParts) >= 5 {
-							limit, unit := memParts[2], memParts[3]
-							limitVal, err := strconv.ParseFloat(limit, 64)
-							if err == nil {
-								// Convert to bytes based on unit
-								switch unit {
-								case "KiB", "KB":
-									container.MemoryLimit = uint64(limitVal * 1024)
-								case "MiB", "MB":
-									container.MemoryLimit = uint64(limitVal * 1024 * 1024)
-								case "GiB", "GB":
-									container.MemoryLimit = uint64(limitVal * 1024 * 1024 * 1024)
-								}
-							}
-						}
-					}
-
-					// Parse memory percentage
-					memPercStr := strings.TrimSuffix(statParts[2], "%")
-					memPerc, err := strconv.ParseFloat(memPercStr, 64)
-					if err == nil {
-						container.MemoryPerc = memPerc
-					}
-				}
+// fillContainerDetails adds network addresses and exit and health state. Failures leave
+// the fields empty, as containers may be removed while being inspected.
+func fillContainerDetails(ctx context.Context, client *docker.Client, container *models.ContainerInfo) {
+	if inspect, err := client.InspectContainer(ctx, container.ID); err == nil {
+		addresses := []string{}
+		for _, network := range inspect.NetworkSettings.Networks {
+			if network.IPAddress != "" {
+				addresses = append(addresses, network.IPAddress)
 			}
+		}
+		sort.Strings(addresses)
+		container.IPAddress = strings.Join(addresses, ", ")
 
-			// Get network info
-			inspectCmd := exec.Command("docker", "inspect", "--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}", container.ID)
-			inspectOutput, err := inspectCmd.Output()
-			if err == nil {
-				container.IPAddress = strings.TrimSpace(string(inspectOutput))
+		state := inspect.State
+		container.StartedAt = parseDockerTime(state.StartedAt)
+		container.FinishedAt = parseDockerTime(state.FinishedAt)
+		container.ExitCode = state.ExitCode
+		container.OOMKilled = state.OOMKilled
+		container.RestartCount = inspect.RestartCount
+		if state.Health != nil {
+			container.Health = state.Health.Status
+			container.FailingStreak = state.Health.FailingStreak
+			for _, check := range state.Health.Log {
+				container.HealthLog = append(container.HealthLog, models.HealthCheckResult{
+					Start:    parseDockerTime(check.Start),
+					End:      parseDockerTime(check.End),
+					ExitCode: check.ExitCode,
+					Output:   strings.TrimSpace(check.Output),
+				})
 			}
+		}
+	}
+}
 
-			// Get port mappings
-			portCmd := exec.Command("docker", "port", container.ID)
-			portOutput, err := portCmd.Output()
-			if err == nil {
-				portLines := strings.Split(string(portOutput), "\n")
-				for _, portLine := range portLines {
-					if portLine != "" {
-						container.Ports = append(container.Ports, strings.TrimSpace(portLine))
-					}
-				}
-			}
+// fillContainerStats fetches the stats of running containers without cgroup stats
+// concurrently. The daemon takes about a second per sample to measure CPU usage.
+func fillContainerStats(ctx context.Context, client *docker.Client, containers []models.ContainerInfo) {
+	semaphore := make(chan struct{}, dockerFetchConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range containers {
+		if containers[i].State != "running" || containers[i].Cgroup != nil {
+			continue
 		}
 
-		result = append(result, container)
+		wg.Add(1)
+		go func(container *models.ContainerInfo) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if stats, err := client.ContainerStats(ctx, container.ID); err == nil {
+				applyContainerStats(container, stats)
+			}
+		}(&containers[i])
+	}
+
+	wg.Wait()
+}
+
+// applyContainerStats sets usage from an Engine API stats sample
+func applyContainerStats(container *models.ContainerInfo, stats docker.Stats) {
+	container.CPUPercent = containerCPUPercent(stats)
+
+	// Page cache can be reclaimed, so leave it out like `docker stats` does
+	memory := stats.MemoryStats
+	used := memory.Usage
+	cache := memory.Stats["inactive_file"] // cgroup v2
+	if cache == 0 {
+		cache = memory.Stats["total_inactive_file"] // cgroup v1
+	}
+	if cache < used {
+		used -= cache
+	}
+
+	container.MemoryUsage = used
+	container.MemoryLimit = memory.Limit
+	if memory.Limit > 0 {
+		container.MemoryPerc = float64(used) / float64(memory.Limit) * 100
+	}
+}
+
+// containerCPUPercent computes CPU usage between the two readings of a stats sample,
+// relative to a single core like `docker stats`
+func containerCPUPercent(stats docker.Stats) float64 {
+	cpuDelta := counterDelta(stats.PreCPUStats.CPUUsage.TotalUsage, stats.CPUStats.CPUUsage.TotalUsage)
+	systemDelta := counterDelta(stats.PreCPUStats.SystemUsage, stats.CPUStats.SystemUsage)
+	if cpuDelta == 0 || systemDelta == 0 {
+		return 0
+	}
+
+	cpus := stats.CPUStats.OnlineCPUs
+	if cpus == 0 {
+		cpus = len(stats.CPUStats.CPUUsage.PercpuUsage)
+	}
+	if cpus == 0 {
+		cpus = 1
 	}
 
-	return result, nil
+	return float64(cpuDelta) / float64(systemDelta) * float64(cpus) * 100
+}
+
+// parseDockerTime parses an Engine API timestamp. Unset times are "0001-01-01T00:00:00Z"
+// and come back as the zero time.
+func parseDockerTime(value string) time.Time {
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || parsed.Year() <= 1 {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// shortContainerID returns the 12 character form of a container ID
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
 }
 
 // isDockerNotInstalled checks if the error is due to Docker not being installed
@@ -292,6 +593,10 @@ func isDockerNotInstalled(err error) bool {
 		return false
 	}
 	
+	if errors.Is(err, docker.ErrDaemonUnavailable) {
+		return true
+	}
+	
 	// Check common error messages that indicate Docker isn't available
 	errorMsg := err.Error()
 	notInstalledPatterns := []string{
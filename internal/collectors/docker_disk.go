@@ -0,0 +1,398 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// The daemon walks every layer and volume to size them, which is slow on busy hosts
+const dockerDiskUsageTimeout = 2 * time.Minute
+
+// Rows listed per detail table unless -verbose is set
+const dockerDiskUsageRows = 10
+
+// GetDockerDiskUsageInfo displays the space used by Docker images, containers, volumes
+// and the build cache, like `docker system df -v`
+func GetDockerDiskUsageInfo(opts models.Options) {
+	usage, err := GetDockerDiskUsage()
+	if err != nil {
+		if isDockerNotInstalled(err) {
+			if opts.JSONOutput {
+				fmt.Println("{}")
+			} else {
+				fmt.Println("Docker or Podman doesn't appear to be installed or isn't running.")
+			}
+			return
+		}
+
+		fmt.Printf("Error getting Docker disk usage: %v\n", err)
+		return
+	}
+
+	if opts.JSONOutput {
+		jsonData, err := json.MarshalIndent(usage, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing Docker disk usage: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	sections := GetDockerDiskUsageSections(usage, opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetDockerDiskUsage fetches the disk usage report from the Docker or Podman daemon
+func GetDockerDiskUsage() (models.DockerDiskUsage, error) {
+	client, err := engineAPIClient()
+	if err != nil {
+		return models.DockerDiskUsage{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerDiskUsageTimeout)
+	defer cancel()
+
+	df, err := client.DiskUsage(ctx)
+	if err != nil {
+		return models.DockerDiskUsage{}, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+
+	return convertDiskUsage(df), nil
+}
+
+// GetDockerDiskUsageSections formats the disk usage report as a summary table followed
+// by the largest images, volumes and build cache records
+func GetDockerDiskUsageSections(usage models.DockerDiskUsage, opts models.Options) map[string][][]string {
+	summaryData := [][]string{
+		{"Type", "Total", "Active", "Size", "Reclaimable"},
+	}
+	var totalSize uint64
+	for _, summary := range usage.Summary {
+		summaryData = append(summaryData, []string{
+			summary.Type,
+			fmt.Sprintf("%d", summary.Total),
+			fmt.Sprintf("%d", summary.Active),
+			formatBytes(summary.Size),
+			formatReclaimable(summary.Reclaimable, summary.Size),
+		})
+		// Dangling images are already part of the image figures
+		if summary.Type != "Dangling Images" {
+			totalSize += summary.Size
+		}
+	}
+	summaryData = append(summaryData, []string{
+		"Total", "", "", formatBytes(totalSize), ui.WarningColor(formatReclaimable(usage.Reclaimable, totalSize)),
+	})
+
+	limit := dockerDiskUsageRows
+	if opts.VerboseOutput {
+		limit = 0
+	}
+
+	sections := map[string][][]string{
+		"Docker Disk": summaryData,
+	}
+
+	if len(usage.Images) > 0 {
+		imageData := [][]string{
+			{"Repository", "Tag", "Image ID", "Size", "Unique", "Containers", "Created"},
+		}
+		for _, image := range usage.Images {
+			row := []string{
+				image.Repository,
+				image.Tag,
+				image.ID,
+				formatBytes(image.Size),
+				formatBytes(image.UniqueSize),
+				fmt.Sprintf("%d", image.Containers),
+				formatOptionalSince(image.Created),
+			}
+			if image.Dangling {
+				row = colorRow(row, ui.DimColor)
+			}
+			imageData = append(imageData, row)
+		}
+		sections["Docker Images"] = limitTableRows(imageData, limit)
+	}
+
+	if len(usage.Volumes) > 0 {
+		volumeData := [][]string{
+			{"Volume", "Driver", "Links", "Size", "Created"},
+		}
+		for _, volume := range usage.Volumes {
+			// Drivers that can't report usage give -1
+			links := "-"
+			if volume.Links >= 0 {
+				links = fmt.Sprintf("%d", volume.Links)
+			}
+			row := []string{
+				shortenMiddle(volume.Name, 40),
+				volume.Driver,
+				links,
+				formatBytes(volume.Size),
+				formatOptionalSince(volume.CreatedAt),
+			}
+			if volume.Links == 0 {
+				row = colorRow(row, ui.DimColor)
+			}
+			volumeData = append(volumeData, row)
+		}
+		sections["Docker Volumes"] = limitTableRows(volumeData, limit)
+	}
+
+	if len(usage.BuildCache) > 0 {
+		cacheData := [][]string{
+			{"Cache ID", "Type", "Size", "Last Used", "Usage", "In Use", "Shared"},
+		}
+		for _, record := range usage.BuildCache {
+			cacheData = append(cacheData, []string{
+				record.ID,
+				record.Type,
+				formatBytes(record.Size),
+				formatOptionalSince(record.LastUsedAt),
+				fmt.Sprintf("%d", record.UsageCount),
+				formatYesNo(record.InUse),
+				formatYesNo(record.Shared),
+			})
+		}
+		sections["Docker Build Cache"] = limitTableRows(cacheData, limit)
+	}
+
+	return sections
+}
+
+// convertDiskUsage turns the daemon's report into the model, sorted largest first, and
+// estimates the space `docker system prune -a --volumes` would reclaim
+func convertDiskUsage(df docker.DiskUsage) models.DockerDiskUsage {
+	usage := models.DockerDiskUsage{
+		Images:     []models.DockerImageUsage{},
+		Containers: []models.DockerContainerUsage{},
+		Volumes:    []models.DockerVolumeUsage{},
+		BuildCache: []models.DockerBuildCacheUsage{},
+	}
+
+	// Layers shared between images are stored once, so unused images only free what
+	// in-use images don't also need
+	images := models.DockerDiskUsageSummary{Type: "Images", Size: nonNegative(df.LayersSize)}
+	dangling := models.DockerDiskUsageSummary{Type: "Dangling Images"}
+	var imagesInUse uint64
+	for _, summary := range df.Images {
+		image := models.DockerImageUsage{
+			ID:         shortImageID(summary.ID),
+			Repository: "<none>",
+			Tag:        "<none>",
+			Size:       nonNegative(summary.Size),
+			SharedSize: nonNegative(summary.SharedSize),
+			Containers: int(summary.Containers),
+			Dangling:   isDanglingImage(summary),
+		}
+		if image.SharedSize < image.Size {
+			image.UniqueSize = image.Size - image.SharedSize
+		}
+		if summary.Created > 0 {
+			image.Created = time.Unix(summary.Created, 0)
+		}
+		if tag, ok := firstImageTag(summary); ok {
+			if i := strings.LastIndex(tag, ":"); i > 0 {
+				image.Repository, image.Tag = tag[:i], tag[i+1:]
+			} else {
+				image.Repository = tag
+			}
+		}
+
+		images.Total++
+		if image.Containers > 0 {
+			images.Active++
+			imagesInUse += image.UniqueSize
+		}
+		if image.Dangling {
+			dangling.Total++
+			dangling.Size += image.UniqueSize
+			if image.Containers > 0 {
+				dangling.Active++
+			} else {
+				dangling.Reclaimable += image.UniqueSize
+			}
+		}
+		usage.Images = append(usage.Images, image)
+	}
+	if imagesInUse < images.Size {
+		images.Reclaimable = images.Size - imagesInUse
+	}
+
+	containers := models.DockerDiskUsageSummary{Type: "Containers"}
+	for _, summary := range df.Containers {
+		container := models.DockerContainerUsage{
+			ID:    shortContainerID(summary.ID),
+			Image: summary.Image,
+			State: summary.State,
+			Size:  nonNegative(summary.SizeRw),
+		}
+		if len(summary.Names) > 0 {
+			container.Name = strings.TrimPrefix(summary.Names[0], "/")
+		}
+
+		containers.Total++
+		containers.Size += container.Size
+		if isActiveContainerState(summary.State) {
+			containers.Active++
+		} else {
+			containers.Reclaimable += container.Size
+		}
+		usage.Containers = append(usage.Containers, container)
+	}
+
+	volumes := models.DockerDiskUsageSummary{Type: "Local Volumes"}
+	for _, summary := range df.Volumes {
+		volume := models.DockerVolumeUsage{
+			Name:      summary.Name,
+			Driver:    summary.Driver,
+			CreatedAt: parseDockerTime(summary.CreatedAt),
+		}
+		if summary.UsageData != nil {
+			volume.Size = nonNegative(summary.UsageData.Size)
+			volume.Links = int(summary.UsageData.RefCount)
+		}
+
+		volumes.Total++
+		volumes.Size += volume.Size
+		if volume.Links > 0 {
+			volumes.Active++
+		} else {
+			volumes.Reclaimable += volume.Size
+		}
+		usage.Volumes = append(usage.Volumes, volume)
+	}
+
+	cache := models.DockerDiskUsageSummary{Type: "Build Cache"}
+	for _, summary := range df.BuildCache {
+		record := models.DockerBuildCacheUsage{
+			ID:          shortImageID(summary.ID),
+			Type:        summary.Type,
+			Description: summary.Description,
+			Size:        nonNegative(summary.Size),
+			InUse:       summary.InUse,
+			Shared:      summary.Shared,
+			UsageCount:  summary.UsageCount,
+			CreatedAt:   parseDockerTime(summary.CreatedAt),
+			LastUsedAt:  parseDockerTime(summary.LastUsedAt),
+		}
+
+		cache.Total++
+		// Shared records are also counted in the image layers
+		if !record.Shared {
+			cache.Size += record.Size
+		}
+		if record.InUse {
+			cache.Active++
+		} else if !record.Shared {
+			cache.Reclaimable += record.Size
+		}
+		usage.BuildCache = append(usage.BuildCache, record)
+	}
+
+	sort.SliceStable(usage.Images, func(i, j int) bool { return usage.Images[i].Size > usage.Images[j].Size })
+	sort.SliceStable(usage.Containers, func(i, j int) bool { return usage.Containers[i].Size > usage.Containers[j].Size })
+	sort.SliceStable(usage.Volumes, func(i, j int) bool { return usage.Volumes[i].Size > usage.Volumes[j].Size })
+	sort.SliceStable(usage.BuildCache, func(i, j int) bool { return usage.BuildCache[i].Size > usage.BuildCache[j].Size })
+
+	// Dangling images are part of the image figures, so they aren't added to the total
+	usage.Summary = []models.DockerDiskUsageSummary{images, dangling, containers, volumes, cache}
+	usage.Reclaimable = images.Reclaimable + containers.Reclaimable + volumes.Reclaimable + cache.Reclaimable
+
+	return usage
+}
+
+// isDanglingImage reports whether an image has no tags left
+func isDanglingImage(image docker.ImageSummary) bool {
+	_, tagged := firstImageTag(image)
+	return !tagged
+}
+
+// firstImageTag returns the first tag of an image, skipping the <none>:<none> placeholder
+func firstImageTag(image docker.ImageSummary) (string, bool) {
+	for _, tag := range image.RepoTags {
+		if tag != "<none>:<none>" {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+// shortImageID returns the 12 character form of an image or cache ID
+func shortImageID(id string) string {
+	return shortContainerID(strings.TrimPrefix(id, "sha256:"))
+}
+
+// nonNegative converts a size the daemon reports as -1 when unknown
+func nonNegative(size int64) uint64 {
+	if size < 0 {
+		return 0
+	}
+	return uint64(size)
+}
+
+// formatReclaimable formats reclaimable space with its share of the total, like `docker system df`
+func formatReclaimable(reclaimable, total uint64) string {
+	if total == 0 {
+		return formatBytes(reclaimable)
+	}
+	return fmt.Sprintf("%s (%.0f%%)", formatBytes(reclaimable), float64(reclaimable)/float64(total)*100)
+}
+
+// formatOptionalSince describes how long ago a time was, or "-" if it's unknown
+func formatOptionalSince(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return formatSince(t)
+}
+
+// formatYesNo formats a flag for a table cell
+func formatYesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// shortenMiddle shortens long names such as anonymous volume IDs, keeping both ends
+func shortenMiddle(value string, width int) string {
+	if len(value) <= width || width < 5 {
+		return value
+	}
+	half := (width - 3) / 2
+	return value[:half] + "..." + value[len(value)-(width-3-half):]
+}
+
+// colorRow applies a color to every cell of a table row
+func colorRow(row []string, colorFunc func(a ...interface{}) string) []string {
+	for i, cell := range row {
+		row[i] = colorFunc(cell)
+	}
+	return row
+}
+
+// limitTableRows keeps the header and the first limit rows of a table, noting how
+// many were left out. A limit of 0 keeps every row.
+func limitTableRows(table [][]string, limit int) [][]string {
+	if limit <= 0 || len(table)-1 <= limit {
+		return table
+	}
+
+	hidden := len(table) - 1 - limit
+	table = table[:limit+1]
+
+	more := make([]string, len(table[0]))
+	more[0] = ui.DimColor(fmt.Sprintf("... %d more (-verbose)", hidden))
+	return append(table, more)
+}
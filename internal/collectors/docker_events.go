@@ -0,0 +1,266 @@
+package collectors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Event types shown when no type filter is given; volume, plugin and daemon events are left out
+var defaultEventTypes = []string{"container", "image", "network"}
+
+// GetDockerEvents prints Docker events as a timeline until interrupted, or until the
+// -until time when it is set. JSON output is written as one object per line.
+func GetDockerEvents(opts models.Options) {
+	client, err := engineAPIClient()
+	if err != nil {
+		fmt.Printf("Error watching Docker events: %v\n", err)
+		return
+	}
+
+	filters := make(map[string][]string, len(opts.Events.Filters)+1)
+	for key, values := range opts.Events.Filters {
+		filters[key] = values
+	}
+	if _, ok := filters["type"]; !ok {
+		filters["type"] = defaultEventTypes
+	}
+
+	stream, err := client.Events(context.Background(), docker.EventOptions{
+		Since:   opts.Events.Since,
+		Until:   opts.Events.Until,
+		Filters: filters,
+	})
+	if err != nil {
+		if isDockerNotInstalled(err) {
+			fmt.Println("Docker or Podman doesn't appear to be installed or isn't running.")
+			return
+		}
+		fmt.Printf("Error watching Docker events: %v\n", err)
+		return
+	}
+	defer stream.Close()
+
+	if !opts.JSONOutput {
+		heading := fmt.Sprintf("Docker events (%s)", describeEventFilters(filters))
+		if opts.Events.Until.IsZero() {
+			heading += ", press Ctrl+C to stop"
+		}
+		fmt.Println(ui.DimColor(heading))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	err = docker.ReadEvents(stream, func(raw docker.Event) error {
+		event := convertDockerEvent(raw)
+		if opts.JSONOutput {
+			return encoder.Encode(event)
+		}
+		fmt.Println(formatEventLine(event))
+		return nil
+	})
+	if err != nil {
+		fmt.Printf("Error reading Docker events: %v\n", err)
+	}
+}
+
+// ParseEventFilters parses a comma-separated list of KEY=VALUE Engine API event filters,
+// such as type=container,event=die,event=oom,container=web-1
+func ParseEventFilters(value string) (map[string][]string, error) {
+	filters := make(map[string][]string)
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		key, filterValue, found := strings.Cut(item, "=")
+		if !found || key == "" || filterValue == "" {
+			return nil, fmt.Errorf("invalid event filter '%s', expected KEY=VALUE", item)
+		}
+		filters[key] = append(filters[key], filterValue)
+	}
+	return filters, nil
+}
+
+// convertDockerEvent turns an Engine API event into the model. Container events carry
+// the container's name, image, labels and exit code in their attributes.
+func convertDockerEvent(raw docker.Event) models.DockerEvent {
+	event := models.DockerEvent{
+		Type:       raw.Type,
+		Action:     raw.Action,
+		ID:         raw.Actor.ID,
+		Attributes: raw.Actor.Attributes,
+	}
+
+	switch {
+	case raw.TimeNano > 0:
+		event.Time = time.Unix(0, raw.TimeNano)
+	case raw.Time > 0:
+		event.Time = time.Unix(raw.Time, 0)
+	}
+
+	// Health checks and exec sessions report as "health_status: healthy" and "exec_start: sh"
+	if action, detail, found := strings.Cut(raw.Action, ": "); found {
+		event.Action = action
+		event.Detail = detail
+	}
+
+	attributes := raw.Actor.Attributes
+	switch raw.Type {
+	case "container":
+		event.ID = shortContainerID(raw.Actor.ID)
+		event.Name = attributes["name"]
+
+		container := &models.ContainerInfo{
+			ID:        event.ID,
+			Name:      attributes["name"],
+			Image:     attributes["image"],
+			Project:   attributes[composeProjectLabel],
+			Service:   attributes[composeServiceLabel],
+			OOMKilled: event.Action == "oom",
+		}
+		if exitCode, err := strconv.Atoi(attributes["exitCode"]); err == nil {
+			container.ExitCode = exitCode
+		}
+		if event.Action == "health_status" {
+			container.Health = event.Detail
+		}
+		switch event.Action {
+		case "start", "restart", "unpause":
+			container.State = "running"
+			container.StartedAt = event.Time
+		case "die":
+			container.State = "exited"
+			container.FinishedAt = event.Time
+		case "pause":
+			container.State = "paused"
+		case "destroy":
+			container.State = "removed"
+		}
+		event.Container = container
+	case "image":
+		// Pulls and tags name the image reference as the actor
+		event.Name = raw.Actor.ID
+		if name := attributes["name"]; name != "" {
+			event.Name = name
+		}
+	default:
+		event.Name = attributes["name"]
+		if event.Name == "" {
+			event.Name = shortContainerID(raw.Actor.ID)
+		}
+	}
+
+	return event
+}
+
+// formatEventLine formats an event as a single timeline line
+func formatEventLine(event models.DockerEvent) string {
+	timestamp := ui.DimColor(event.Time.Local().Format("2006-01-02 15:04:05.000"))
+	action := event.Action
+	if event.Action == "health_status" {
+		action = "health"
+	}
+
+	return fmt.Sprintf("%s  %-9s %s %-24s %s",
+		timestamp,
+		event.Type,
+		eventColor(event)(fmt.Sprintf("%-10s", action)),
+		event.Name,
+		describeEvent(event),
+	)
+}
+
+// describeEvent lists the details worth showing for an event
+func describeEvent(event models.DockerEvent) string {
+	attributes := event.Attributes
+	details := []string{}
+
+	switch event.Type {
+	case "container":
+		container := event.Container
+		if container.Project != "" {
+			details = append(details, container.Project+"/"+container.Service)
+		}
+		switch event.Action {
+		case "die":
+			details = append(details, fmt.Sprintf("exit code %d", container.ExitCode))
+		case "kill":
+			if signal := attributes["signal"]; signal != "" {
+				details = append(details, "signal "+signal)
+			}
+		case "health_status":
+			details = append(details, healthColor(container.Health))
+		case "exec_start", "exec_create":
+			details = append(details, event.Detail)
+		}
+		details = append(details, ui.DimColor(container.Image))
+	case "network":
+		if container := attributes["container"]; container != "" {
+			details = append(details, "container "+shortContainerID(container))
+		}
+		if driver := attributes["type"]; driver != "" {
+			details = append(details, ui.DimColor(driver))
+		}
+	default:
+		keys := make([]string, 0, len(attributes))
+		for key := range attributes {
+			if key != "name" {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			details = append(details, ui.DimColor(key+"="+attributes[key]))
+		}
+	}
+
+	return strings.Join(details, "  ")
+}
+
+// eventColor picks the color of an event's action
+func eventColor(event models.DockerEvent) func(a ...interface{}) string {
+	switch event.Action {
+	case "oom":
+		return ui.DangerColor
+	case "die":
+		if event.Container != nil && event.Container.ExitCode == 0 {
+			return ui.WarningColor
+		}
+		return ui.DangerColor
+	case "kill", "stop", "destroy", "delete", "untag", "disconnect", "pause":
+		return ui.WarningColor
+	case "start", "restart", "pull", "connect", "unpause", "create":
+		return ui.SuccessColor
+	case "health_status":
+		if event.Detail == "unhealthy" {
+			return ui.DangerColor
+		}
+		return ui.SuccessColor
+	}
+	return ui.DimColor
+}
+
+// describeEventFilters summarizes the filters for the timeline heading
+func describeEventFilters(filters map[string][]string) string {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+strings.Join(filters[key], "|"))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -1,11 +1,15 @@
 package collectors
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
-	"math/rand"
+	"os"
+	"os/exec"
+	"regexp"
 	"runtime"
-	"time"
+	"strconv"
+	"strings"
 
 	"github.com/tiwariParth/whosay/internal/models"
 	"github.com/tiwariParth/whosay/internal/ui"
@@ -30,16 +34,24 @@ func GetMemoryInfo(opts models.Options) {
 func GetMemoryInfoSections(opts models.Options) map[string][][]string {
 	info := collectMemoryInfo()
 	
-	// Calculate sizes in more readable units with consistent formatting
-	totalMB := float64(info.Total) / 1024 / 1024
-	usedMB := float64(info.Used) / 1024 / 1024
-	freeMB := totalMB - usedMB
-	
-	// Create compact data structure with consistent formatting
+	// Used is what the kernel cannot hand out without swapping, i.e. total minus available
 	memData := [][]string{
-		{"Total", fmt.Sprintf("%.1f MB", totalMB)},
-		{"Used", fmt.Sprintf("%.1f MB", usedMB)},
-		{"Free", fmt.Sprintf("%.1f MB", freeMB)},
+		{"Total", formatBytes(info.Total)},
+		{"Used", formatBytes(info.Used)},
+		{"Available", formatBytes(info.Available)},
+	}
+	
+	// Page cache and buffers are reclaimable, so show them separately from used memory
+	if info.Buffers > 0 || info.Cached > 0 {
+		memData = append(memData, []string{"Buff/Cache", formatBytes(info.Buffers + info.Cached)})
+	}
+	
+	if opts.VerboseOutput {
+		memData = append(memData, []string{"Free", formatBytes(info.Free)})
+		memData = append(memData, []string{"Shared", formatBytes(info.Shared)})
+		memData = append(memData, []string{"Slab", formatBytes(info.Slab)})
+		memData = append(memData, []string{"Dirty", formatBytes(info.Dirty)})
+		memData = append(memData, []string{"Writeback", formatBytes(info.Writeback)})
 	}
 	
 	// Add usage bar with consistent width
@@ -52,28 +64,188 @@ func GetMemoryInfoSections(opts models.Options) map[string][][]string {
 		"Usage", ui.PrintCompactUsageBar("", info.UsagePerc, barWidth),
 	})
 	
+	// The container's limit is what gets whosay OOM killed, not the host's total
+	if info.Container != nil {
+		memData = append(memData, getContainerMemoryRows(*info.Container, info.Total, barWidth)...)
+	}
+	
 	// Return data for unified display
 	return map[string][][]string{
 		"Memory": memData,
+		"Swap":   getSwapSection(info.Swap, opts),
 	}
 }
 
 // collectMemoryInfo gathers memory information
 func collectMemoryInfo() models.MemoryInfo {
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
+	var info models.MemoryInfo
+	var meminfo map[string]uint64
 
-	// Add some randomness to make watch mode interesting
-	rand.Seed(time.Now().UnixNano())
-	randomFactor := 1.0
-	
-	// Add up to 5% random variation to make watch mode interesting
-	randomFactor = 0.95 + (rand.Float64() * 0.1) // 0.95 to 1.05
+	switch runtime.GOOS {
+	case "linux":
+		var err error
+		if meminfo, err = readMemInfo(); err == nil {
+			info = memoryFromMemInfo(meminfo)
+		}
+	case "darwin":
+		info = getDarwinMemoryInfo()
+	case "windows":
+		info = getWindowsMemoryInfo()
+	}
+
+	if info.Total > 0 {
+		info.UsagePerc = float64(info.Used) / float64(info.Total) * 100
+	}
+
+	info.Swap = collectSwapInfo(meminfo)
+	info.Container = collectContainerMemory()
+
+	return info
+}
+
+// readMemInfo parses /proc/meminfo into a map of field name to bytes
+func readMemInfo() (map[string]uint64, error) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// Lines look like "MemTotal:       16318480 kB"
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		fields := strings.Fields(parts[1])
+		if len(fields) == 0 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if len(fields) > 1 && fields[1] == "kB" {
+			value *= 1024
+		}
+
+		result[parts[0]] = value
+	}
+
+	return result, scanner.Err()
+}
+
+// memoryFromMemInfo builds memory information from parsed /proc/meminfo fields
+func memoryFromMemInfo(meminfo map[string]uint64) models.MemoryInfo {
+	info := models.MemoryInfo{
+		Total:     meminfo["MemTotal"],
+		Free:      meminfo["MemFree"],
+		Buffers:   meminfo["Buffers"],
+		Cached:    meminfo["Cached"] + meminfo["SReclaimable"],
+		Shared:    meminfo["Shmem"],
+		Slab:      meminfo["Slab"],
+		Dirty:     meminfo["Dirty"],
+		Writeback: meminfo["Writeback"],
+	}
 
-	// This is a simplified version for demo
-	return models.MemoryInfo{
-		Total:     m.Sys,
-		Used:      uint64(float64(m.Alloc) * randomFactor),
-		UsagePerc: float64(m.Alloc) / float64(m.Sys) * 100 * randomFactor,
+	// MemAvailable exists since Linux 3.14; estimate it on older kernels
+	if available, ok := meminfo["MemAvailable"]; ok {
+		info.Available = available
+	} else {
+		info.Available = info.Free + info.Buffers + info.Cached
 	}
+
+	if info.Available > info.Total {
+		info.Available = info.Total
+	}
+	info.Used = info.Total - info.Available
+
+	return info
+}
+
+// getDarwinMemoryInfo collects memory info on macOS using sysctl and vm_stat
+func getDarwinMemoryInfo() models.MemoryInfo {
+	var info models.MemoryInfo
+
+	output, err := exec.Command("sysctl", "-n", "hw.memsize").Output()
+	if err != nil {
+		return info
+	}
+	info.Total, _ = strconv.ParseUint(strings.TrimSpace(string(output)), 10, 64)
+
+	output, err = exec.Command("vm_stat").Output()
+	if err != nil {
+		return info
+	}
+
+	// vm_stat reports page counts, with the page size in its header
+	pageSize := uint64(4096)
+	if matches := regexp.MustCompile(`page size of (\d+) bytes`).FindStringSubmatch(string(output)); len(matches) == 2 {
+		pageSize, _ = strconv.ParseUint(matches[1], 10, 64)
+	}
+
+	pages := make(map[string]uint64)
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(parts[1]), "."), 10, 64)
+		if err == nil {
+			pages[parts[0]] = value
+		}
+	}
+
+	info.Free = pages["Pages free"] * pageSize
+	info.Cached = (pages["File-backed pages"] + pages["Pages purgeable"]) * pageSize
+	info.Available = (pages["Pages free"] + pages["Pages inactive"] + pages["Pages purgeable"] + pages["Pages speculative"]) * pageSize
+
+	if info.Available > info.Total {
+		info.Available = info.Total
+	}
+	info.Used = info.Total - info.Available
+
+	return info
+}
+
+// getWindowsMemoryInfo collects memory info on Windows using wmic
+func getWindowsMemoryInfo() models.MemoryInfo {
+	var info models.MemoryInfo
+
+	cmd := exec.Command("wmic", "OS", "get", "FreePhysicalMemory,TotalVisibleMemorySize", "/format:csv")
+	output, err := cmd.Output()
+	if err != nil {
+		return info
+	}
+
+	// CSV columns are Node,FreePhysicalMemory,TotalVisibleMemorySize in KB
+	for _, line := range strings.Split(string(output), "\n") {
+		parts := strings.Split(strings.TrimSpace(line), ",")
+		if len(parts) < 3 {
+			continue
+		}
+
+		free, errFree := strconv.ParseUint(parts[1], 10, 64)
+		total, errTotal := strconv.ParseUint(parts[2], 10, 64)
+		if errFree != nil || errTotal != nil {
+			continue
+		}
+
+		info.Total = total * 1024
+		info.Free = free * 1024
+		info.Available = info.Free
+		if info.Available > info.Total {
+			info.Available = info.Total
+		}
+		info.Used = info.Total - info.Available
+		break
+	}
+
+	return info
 }
@@ -3,12 +3,15 @@ package collectors
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"regexp"
 	"runtime"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tiwariParth/whosay/internal/models"
 	"github.com/tiwariParth/whosay/internal/ui"
@@ -16,23 +19,27 @@ import (
 
 // GetProcessInfo displays information about running processes
 func GetProcessInfo(opts models.Options) {
-	// Create default display options
-	display := models.ProcessDisplay{
-		SortBy:    "cpu",
-		Ascending: false,
-		Filter:    "",
-		Limit:     10,
+	if opts.Process.Tree {
+		GetProcessTreeInfo(opts)
+		return
 	}
 
-	// Get processes
-	processes, err := GetTopProcesses(display.Limit)
-	if err != nil {
+	if opts.Process.GroupBy != "" {
+		GetProcessGroupInfo(opts)
+		return
+	}
+
+	if err := ValidateProcessDisplay(opts.Process); err != nil {
 		fmt.Printf("Error getting process information: %v\n", err)
 		return
 	}
 
-	// Apply sorting
-	sortProcesses(processes, display.SortBy, display.Ascending)
+	allProcesses, err := GetTopProcesses(0)
+	if err != nil {
+		fmt.Printf("Error getting process information: %v\n", err)
+		return
+	}
+	processes := ApplyProcessDisplay(allProcesses, opts.Process)
 
 	if opts.JSONOutput {
 		jsonData, err := json.MarshalIndent(processes, "", "  ")
@@ -46,38 +53,85 @@ func GetProcessInfo(opts models.Options) {
 
 	// Format and display the process info in compact view
 	sections := GetProcessInfoSections(processes, opts)
+	for k, v := range GetProcessIOSections(allProcesses, opts) {
+		sections[k] = v
+	}
 	ui.CompactDisplay(sections)
 }
 
 // GetProcessInfoSections formats process information for the compact display
 func GetProcessInfoSections(processes []models.ProcessInfo, opts models.Options) map[string][][]string {
+    display := opts.Process
+    sortBy := strings.ToLower(display.SortBy)
+    if sortBy == "" {
+        sortBy = "cpu"
+    }
+    
+    order := "descending"
+    if display.Ascending {
+        order = "ascending"
+    }
+    
     // Create the process section
     processData := [][]string{
         {"Count", fmt.Sprintf("%d", len(processes))},
+        {"Sort", fmt.Sprintf("%s (%s)", sortBy, order)},
+    }
+    
+    if display.Filter != "" {
+        processData = append(processData, []string{"Filter", display.Filter})
+    }
+    if display.User != "" {
+        processData = append(processData, []string{"User", display.User})
+    }
+
+    // Show the sort column when it isn't one of the default columns
+    extraHeader := ""
+    switch sortBy {
+    case "rss":
+        extraHeader = "RSS"
+    case "start":
+        extraHeader = "Started"
+    case "threads":
+        extraHeader = "Threads"
+    case "io":
+        extraHeader = "I/O/s"
     }
 
     // Format top processes for display with a simpler table layout
-    topProcSection := [][]string{
-        {"PID", "Name", "CPU%", "Memory%"},
+    header := []string{"PID", "Name", "User", "CPU%", "Memory%"}
+    if extraHeader != "" {
+        header = append(header, extraHeader)
     }
+    topProcSection := [][]string{header}
 
     // Add process data with proper column separation
-    for i, proc := range processes {
-        if i >= 10 { // Only show top 10 in compact view
-            break
-        }
-
+    for _, proc := range processes {
         procName := proc.Name
         if len(procName) > 15 {
             procName = procName[:12] + "..." // Truncate long names
         }
 
-        topProcSection = append(topProcSection, []string{
+        row := []string{
             fmt.Sprintf("%d", proc.PID),
             procName,
+            proc.User,
             fmt.Sprintf("%.1f", proc.CPU),
             fmt.Sprintf("%.1f", proc.Memory),
-        })
+        }
+        
+        switch sortBy {
+        case "rss":
+            row = append(row, formatBytes(proc.MemoryRSS*1024))
+        case "start":
+            row = append(row, formatStartTime(proc.StartTime))
+        case "threads":
+            row = append(row, fmt.Sprintf("%d", proc.Threads))
+        case "io":
+            row = append(row, formatBytes(uint64(proc.IOReadRate+proc.IOWriteRate))+"/s")
+        }
+
+        topProcSection = append(topProcSection, row)
     }
 
     // Build the final sections map
@@ -93,6 +147,161 @@ func GetProcessInfoSections(processes []models.ProcessInfo, opts models.Options)
     return result
 }
 
+// SelectProcesses returns the running processes matching the display filters,
+// sorted and limited as requested
+func SelectProcesses(display models.ProcessDisplay) ([]models.ProcessInfo, error) {
+	if err := ValidateProcessDisplay(display); err != nil {
+		return nil, err
+	}
+
+	processes, err := GetTopProcesses(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return ApplyProcessDisplay(processes, display), nil
+}
+
+// ApplyProcessDisplay filters, sorts and limits an already collected process list.
+// The display options must have been validated with ValidateProcessDisplay.
+func ApplyProcessDisplay(processes []models.ProcessInfo, display models.ProcessDisplay) []models.ProcessInfo {
+	processes = filterProcessDisplay(processes, display)
+
+	sortBy := display.SortBy
+	if sortBy == "" {
+		sortBy = "cpu"
+	}
+	sortProcesses(processes, sortBy, display.Ascending)
+
+	if display.Limit > 0 && len(processes) > display.Limit {
+		processes = processes[:display.Limit]
+	}
+
+	fillSocketCounts(processes)
+	return processes
+}
+
+// filterProcessDisplay returns a copy of the processes matching the filter and user options
+func filterProcessDisplay(processes []models.ProcessInfo, display models.ProcessDisplay) []models.ProcessInfo {
+	// Filter is a case-insensitive regular expression over name and command line
+	if display.Filter != "" {
+		filterRegex := regexp.MustCompile("(?i)" + display.Filter)
+		return filterProcessUser(filterProcesses(processes, filterRegex), display.User)
+	}
+
+	return filterProcessUser(processes, display.User)
+}
+
+// filterProcessUser returns a copy of the processes owned by user, or all of them if user is empty
+func filterProcessUser(processes []models.ProcessInfo, user string) []models.ProcessInfo {
+	filtered := []models.ProcessInfo{}
+	for _, proc := range processes {
+		if user == "" || proc.User == user {
+			filtered = append(filtered, proc)
+		}
+	}
+	return filtered
+}
+
+// Number of processes listed in the Top I/O section
+const topIOCount = 5
+
+// GetProcessIOSections ranks the processes doing the most disk I/O since the last sample
+func GetProcessIOSections(processes []models.ProcessInfo, opts models.Options) map[string][][]string {
+	// Per-process I/O accounting comes from /proc/[pid]/io
+	if runtime.GOOS != "linux" {
+		return map[string][][]string{}
+	}
+
+	busy := []models.ProcessInfo{}
+	for _, proc := range filterProcessDisplay(processes, opts.Process) {
+		if proc.IOReadRate+proc.IOWriteRate > 0 {
+			busy = append(busy, proc)
+		}
+	}
+
+	if len(busy) == 0 {
+		return map[string][][]string{
+			"Top I/O": {
+				{"Status", "No disk I/O since the last sample"},
+			},
+		}
+	}
+
+	sortProcesses(busy, "io", false)
+	if len(busy) > topIOCount {
+		busy = busy[:topIOCount]
+	}
+	fillSocketCounts(busy)
+
+	ioData := [][]string{
+		{"PID", "Name", "Read/s", "Write/s", "Sockets"},
+	}
+	for _, proc := range busy {
+		procName := proc.Name
+		if len(procName) > 15 {
+			procName = procName[:12] + "..."
+		}
+
+		ioData = append(ioData, []string{
+			fmt.Sprintf("%d", proc.PID),
+			procName,
+			formatBytes(uint64(proc.IOReadRate)) + "/s",
+			formatBytes(uint64(proc.IOWriteRate)) + "/s",
+			fmt.Sprintf("%d", proc.Sockets),
+		})
+	}
+
+	return map[string][][]string{
+		"Top I/O": ioData,
+	}
+}
+
+// fillSocketCounts sets the socket count of each process. Counting means reading every
+// descriptor link, so it's only done for the processes that are shown.
+func fillSocketCounts(processes []models.ProcessInfo) {
+	if runtime.GOOS != "linux" {
+		return
+	}
+
+	for i := range processes {
+		if sockets, err := countSockets(processes[i].PID); err == nil {
+			processes[i].Sockets = sockets
+		}
+	}
+}
+
+// ValidateProcessDisplay checks the sort key and filter expression of the display options
+func ValidateProcessDisplay(display models.ProcessDisplay) error {
+	if display.SortBy != "" && !isProcessSortKey(display.SortBy) {
+		return fmt.Errorf("unknown sort key '%s' (use one of: %s)", display.SortBy, strings.Join(processSortKeys, ", "))
+	}
+
+	if display.Filter != "" {
+		if _, err := regexp.Compile("(?i)" + display.Filter); err != nil {
+			return fmt.Errorf("invalid filter expression: %v", err)
+		}
+	}
+
+	if display.Limit < 0 {
+		return fmt.Errorf("process limit must not be negative")
+	}
+
+	if display.GroupBy != "" {
+		if !isProcessGroupKey(display.GroupBy) {
+			return fmt.Errorf("unknown group key '%s' (use one of: %s)", display.GroupBy, strings.Join(processGroupKeys, ", "))
+		}
+		if display.Tree {
+			return fmt.Errorf("process grouping can't be combined with the process tree")
+		}
+		if display.SortBy != "" && !isProcessGroupSortKey(display.SortBy) {
+			return fmt.Errorf("sort key '%s' doesn't apply to process groups (use one of: %s)", display.SortBy, strings.Join(processGroupSortKeys, ", "))
+		}
+	}
+
+	return nil
+}
+
 // GetTopProcesses returns the top processes by CPU or memory usage
 func GetTopProcesses(limit int) ([]models.ProcessInfo, error) {
 	switch runtime.GOOS {
@@ -107,115 +316,225 @@ func GetTopProcesses(limit int) ([]models.ProcessInfo, error) {
 	}
 }
 
-// FindProcess returns processes matching the given name pattern
-func FindProcess(name string) ([]models.ProcessInfo, error) {
-	processes, err := GetTopProcesses(100) // Get a larger set to search in
+// Linux truncates process names (comm) to 15 characters
+const processNameMaxLen = 15
+
+// FindProcess returns the processes with exactly the given name or, with matchCommandLine,
+// every process whose name or command line contains it, ignoring case
+func FindProcess(name string, matchCommandLine bool) ([]models.ProcessInfo, error) {
+	processes, err := GetTopProcesses(0) // Search all processes, not just the busiest ones
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter processes by name using case-insensitive matching
-	nameRegex, err := regexp.Compile(fmt.Sprintf("(?i)%s", regexp.QuoteMeta(name)))
-	if err != nil {
-		return nil, fmt.Errorf("invalid search pattern: %v", err)
+	if matchCommandLine {
+		nameRegex, err := regexp.Compile(fmt.Sprintf("(?i)%s", regexp.QuoteMeta(name)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid search pattern: %v", err)
+		}
+		return filterProcesses(processes, nameRegex), nil
 	}
 
+	matches := []models.ProcessInfo{}
+	for _, proc := range processes {
+		if processNameMatches(proc.Name, name) {
+			matches = append(matches, proc)
+		}
+	}
+	return matches, nil
+}
+
+// processNameMatches compares a process name with a target, allowing for the kernel
+// having cut long names short
+func processNameMatches(name, target string) bool {
+	if name == target {
+		return true
+	}
+	return runtime.GOOS == "linux" && len(name) == processNameMaxLen && len(target) > processNameMaxLen &&
+		strings.HasPrefix(target, name)
+}
+
+// filterProcesses returns the processes whose name or command line matches the expression
+func filterProcesses(processes []models.ProcessInfo, pattern *regexp.Regexp) []models.ProcessInfo {
 	filtered := []models.ProcessInfo{}
 	for _, proc := range processes {
-		if nameRegex.MatchString(proc.Name) || nameRegex.MatchString(proc.CommandLine) {
+		if pattern.MatchString(proc.Name) || pattern.MatchString(proc.CommandLine) {
 			filtered = append(filtered, proc)
 		}
 	}
 
-	return filtered, nil
+	return filtered
+}
+
+// Interval used for the second reading when no previous process sample exists
+const processSampleInterval = 250 * time.Millisecond
+
+// procKey identifies a process across samples, guarding against PID reuse
+type procKey struct {
+	PID        int
+	StartTicks uint64
 }
 
-// getLinuxProcesses gets process information on Linux
+// procIOSample holds the storage I/O counters of a process
+type procIOSample struct {
+	ReadBytes  uint64
+	WriteBytes uint64
+}
+
+// Process CPU time and I/O store, kept across watch mode ticks
+var (
+	lastProcJiffies  map[procKey]uint64
+	lastProcIO       map[procKey]procIOSample
+	lastProcSampleAt time.Time
+	procSampleMu     sync.Mutex
+)
+
+// getLinuxProcesses gets process information on Linux by reading /proc directly
 func getLinuxProcesses(limit int) ([]models.ProcessInfo, error) {
-	result := []models.ProcessInfo{}
+	procSampleMu.Lock()
+	defer procSampleMu.Unlock()
 
-	// Run ps command to get process info
-	cmd := exec.Command("ps", "-eo", "pid,ppid,user,%cpu,%mem,rss,stat,start,comm,cmd", "--sort=-%cpu")
-	output, err := cmd.Output()
+	stats, err := readAllProcStats()
 	if err != nil {
-		return result, fmt.Errorf("failed to run ps command: %w", err)
+		return []models.ProcessInfo{}, fmt.Errorf("failed to read /proc: %w", err)
 	}
 
-	lines := strings.Split(string(output), "\n")
-	if len(lines) <= 1 {
-		return result, nil
+	// Without a previous reading, take a second one after a short interval
+	if lastProcJiffies == nil {
+		lastProcJiffies = procJiffies(stats)
+		lastProcIO = readAllProcIO(stats)
+		lastProcSampleAt = time.Now()
+		time.Sleep(processSampleInterval)
+
+		stats, err = readAllProcStats()
+		if err != nil {
+			return []models.ProcessInfo{}, fmt.Errorf("failed to read /proc: %w", err)
+		}
 	}
 
-	// Skip the header line
-	lines = lines[1:]
+	currentIO := readAllProcIO(stats)
 
-	// Parse each line
-	for _, line := range lines {
-		if line == "" {
-			continue
-		}
+	now := time.Now()
+	elapsed := now.Sub(lastProcSampleAt).Seconds()
+	if elapsed < 0.1 {
+		elapsed = 0.1 // Prevent division by zero or unrealistically small time periods
+	}
 
-		fields := strings.Fields(line)
-		if len(fields) < 9 {
-			continue // Skip invalid lines
-		}
+	var memTotal uint64
+	if meminfo, err := readMemInfo(); err == nil {
+		memTotal = meminfo["MemTotal"]
+	}
+	pageSize := uint64(os.Getpagesize())
 
-		// Extract process info
-		pid, err := strconv.Atoi(fields[0])
-		if err != nil {
-			continue
-		}
+	result := make([]models.ProcessInfo, 0, len(stats))
+	for _, stat := range stats {
+		proc := linuxProcessInfo(stat, memTotal, pageSize)
 
-		ppid, _ := strconv.Atoi(fields[1])
-		user := fields[2]
-		
-		cpu, err := strconv.ParseFloat(fields[3], 64)
-		if err != nil {
-			cpu = 0.0
-		}
-		
-		mem, err := strconv.ParseFloat(fields[4], 64)
-		if err != nil {
-			mem = 0.0
-		}
-		
-		rss, err := strconv.ParseUint(fields[5], 10, 64)
-		if err != nil {
-			rss = 0
-		}
-		
-		status := fields[6]
-		name := fields[8]
-		
-		// Join remaining fields for command line
-		cmdLine := ""
-		if len(fields) >= 10 {
-			cmdLine = strings.Join(fields[9:], " ")
+		// CPU usage is relative to a single core, like top and ps
+		key := procKey{PID: stat.PID, StartTicks: stat.StartTicks}
+		if prev, ok := lastProcJiffies[key]; ok {
+			ticks := counterDelta(prev, stat.UTime+stat.STime)
+			proc.CPU = float64(ticks) / clockTicksPerSecond / elapsed * 100
 		}
 
-		proc := models.ProcessInfo{
-			PID:         pid,
-			PPID:        ppid,
-			Name:        name,
-			User:        user,
-			CPU:         cpu,
-			Memory:      mem,
-			MemoryRSS:   rss,
-			Status:      status,
-			CommandLine: cmdLine,
+		// I/O counters are only readable for our own processes unless running as root
+		if cur, ok := currentIO[key]; ok {
+			if prev, ok := lastProcIO[key]; ok {
+				proc.IOReadRate = float64(counterDelta(prev.ReadBytes, cur.ReadBytes)) / elapsed
+				proc.IOWriteRate = float64(counterDelta(prev.WriteBytes, cur.WriteBytes)) / elapsed
+			}
 		}
 
 		result = append(result, proc)
+	}
 
-		// Stop if we have enough processes
-		if len(result) >= limit {
-			break
-		}
+	lastProcJiffies = procJiffies(stats)
+	lastProcIO = currentIO
+	lastProcSampleAt = now
+
+	sortProcesses(result, "cpu", false)
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
 	}
 
 	return result, nil
 }
 
+// linuxProcessInfo describes a process from its stat, with everything but CPU and I/O rates
+func linuxProcessInfo(stat procStat, memTotal, pageSize uint64) models.ProcessInfo {
+	proc := models.ProcessInfo{
+		PID:       stat.PID,
+		PPID:      stat.PPID,
+		Name:      stat.Name,
+		Status:    stat.State,
+		MemoryRSS: stat.RSSPages * pageSize / 1024,
+		StartTime: processStartTime(stat.StartTicks),
+		Threads:   stat.Threads,
+	}
+
+	if memTotal > 0 {
+		proc.Memory = float64(stat.RSSPages*pageSize) / float64(memTotal) * 100
+	}
+
+	// The process may have exited since its stat was read
+	if status, err := readProcStatus(stat.PID); err == nil {
+		proc.User = lookupUsername(statusUID(status))
+	}
+
+	// Kernel threads have no command line, show their name like ps does
+	proc.CommandLine = readProcCmdline(stat.PID)
+	if proc.CommandLine == "" {
+		proc.CommandLine = "[" + stat.Name + "]"
+	}
+
+	return proc
+}
+
+// readAllProcStats reads /proc/[pid]/stat for every running process
+func readAllProcStats() ([]procStat, error) {
+	pids, err := listPIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]procStat, 0, len(pids))
+	for _, pid := range pids {
+		// Processes can exit between listing and reading, so skip failures
+		stat, err := readProcStat(pid)
+		if err != nil {
+			continue
+		}
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// procJiffies maps each process to its total CPU time in clock ticks
+func procJiffies(stats []procStat) map[procKey]uint64 {
+	result := make(map[procKey]uint64, len(stats))
+	for _, stat := range stats {
+		result[procKey{PID: stat.PID, StartTicks: stat.StartTicks}] = stat.UTime + stat.STime
+	}
+	return result
+}
+
+// readAllProcIO reads the storage I/O counters of every process that allows it
+func readAllProcIO(stats []procStat) map[procKey]procIOSample {
+	result := make(map[procKey]procIOSample, len(stats))
+	for _, stat := range stats {
+		io, err := readProcIO(stat.PID)
+		if err != nil {
+			continue
+		}
+		result[procKey{PID: stat.PID, StartTicks: stat.StartTicks}] = procIOSample{
+			ReadBytes:  io.ReadBytes,
+			WriteBytes: io.WriteBytes,
+		}
+	}
+	return result
+}
+
 // getDarwinProcesses gets process information on macOS
 func getDarwinProcesses(limit int) ([]models.ProcessInfo, error) {
 	result := []models.ProcessInfo{}
@@ -287,7 +606,7 @@ func getDarwinProcesses(limit int) ([]models.ProcessInfo, error) {
 		result = append(result, proc)
 
 		// Stop if we have enough processes
-		if len(result) >= limit {
+		if limit > 0 && len(result) >= limit {
 			break
 		}
 	}
@@ -378,7 +697,7 @@ func getWindowsProcesses(limit int) ([]models.ProcessInfo, error) {
 		result = append(result, proc)
 
 		// Stop if we have enough processes
-		if len(result) >= limit {
+		if limit > 0 && len(result) >= limit {
 			break
 		}
 	}
@@ -386,6 +705,33 @@ func getWindowsProcesses(limit int) ([]models.ProcessInfo, error) {
 	return result, nil
 }
 
+// Keys accepted by sortProcesses
+var processSortKeys = []string{"cpu", "mem", "memory", "pid", "name", "rss", "start", "threads", "io"}
+
+// isProcessSortKey reports whether the key is a supported sort field
+func isProcessSortKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, candidate := range processSortKeys {
+		if candidate == key {
+			return true
+		}
+	}
+	return false
+}
+
+// formatStartTime shows the time for processes started today and the date otherwise
+func formatStartTime(start time.Time) string {
+	if start.IsZero() {
+		return "-"
+	}
+
+	now := time.Now()
+	if start.Year() == now.Year() && start.YearDay() == now.YearDay() {
+		return start.Format("15:04:05")
+	}
+	return start.Format("Jan 02")
+}
+
 // sortProcesses sorts the process list by the given field
 func sortProcesses(processes []models.ProcessInfo, sortBy string, ascending bool) {
 	switch strings.ToLower(sortBy) {
@@ -417,5 +763,35 @@ func sortProcesses(processes []models.ProcessInfo, sortBy string, ascending bool
 			}
 			return processes[i].Name > processes[j].Name
 		})
+	case "rss":
+		sort.Slice(processes, func(i, j int) bool {
+			if ascending {
+				return processes[i].MemoryRSS < processes[j].MemoryRSS
+			}
+			return processes[i].MemoryRSS > processes[j].MemoryRSS
+		})
+	case "start":
+		sort.Slice(processes, func(i, j int) bool {
+			if ascending {
+				return processes[i].StartTime.Before(processes[j].StartTime)
+			}
+			return processes[i].StartTime.After(processes[j].StartTime)
+		})
+	case "threads":
+		sort.Slice(processes, func(i, j int) bool {
+			if ascending {
+				return processes[i].Threads < processes[j].Threads
+			}
+			return processes[i].Threads > processes[j].Threads
+		})
+	case "io":
+		sort.Slice(processes, func(i, j int) bool {
+			ioI := processes[i].IOReadRate + processes[i].IOWriteRate
+			ioJ := processes[j].IOReadRate + processes[j].IOWriteRate
+			if ascending {
+				return ioI < ioJ
+			}
+			return ioI > ioJ
+		})
 	}
 }
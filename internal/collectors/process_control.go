@@ -0,0 +1,249 @@
+package collectors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// How often to check whether a terminated process has exited
+const terminatePollInterval = 100 * time.Millisecond
+
+// I/O scheduling classes accepted by ionice, see ioprio_set(2)
+var ioPriorityClasses = map[string]int{
+	"none":        0,
+	"realtime":    1,
+	"best-effort": 2,
+	"idle":        3,
+}
+
+// ResolveProcessTargets turns a PID or a process name into the processes it refers to.
+// Names go through FindProcess, matching exactly unless matchCommandLine is set. whosay
+// and the processes it was started from, such as sudo or a shell, are never targets.
+func ResolveProcessTargets(target string, matchCommandLine bool) ([]models.ProcessInfo, error) {
+	if pid, err := strconv.Atoi(target); err == nil {
+		if pid <= 0 {
+			return nil, fmt.Errorf("'%s' is not a valid PID", target)
+		}
+
+		processes, err := GetTopProcesses(0)
+		if err != nil {
+			return nil, err
+		}
+		for _, proc := range processes {
+			if proc.PID == pid {
+				return []models.ProcessInfo{proc}, nil
+			}
+		}
+		return nil, fmt.Errorf("no process with PID %d", pid)
+	}
+
+	matches, err := FindProcess(target, matchCommandLine)
+	if err != nil {
+		return nil, err
+	}
+
+	// The command lines of whosay and of the sudo or shell running it contain the target
+	excluded := selfAndAncestors()
+	targets := []models.ProcessInfo{}
+	for _, proc := range matches {
+		if !excluded[proc.PID] {
+			targets = append(targets, proc)
+		}
+	}
+
+	if len(targets) == 0 {
+		if matchCommandLine {
+			return nil, fmt.Errorf("no process name or command line contains '%s'", target)
+		}
+		return nil, fmt.Errorf("no process is named '%s' (use -cmdline to match command lines)", target)
+	}
+
+	sortProcesses(targets, "pid", true)
+	return targets, nil
+}
+
+// selfAndAncestors returns the PIDs of whosay and of every process it descends from.
+// Parents are read from /proc, elsewhere from the process list.
+func selfAndAncestors() map[int]bool {
+	parentOf := func(pid int) int {
+		stat, err := readProcStat(pid)
+		if err != nil {
+			return 0
+		}
+		return stat.PPID
+	}
+
+	if runtime.GOOS != "linux" {
+		parents := make(map[int]int)
+		if all, err := GetTopProcesses(0); err == nil {
+			for _, proc := range all {
+				parents[proc.PID] = proc.PPID
+			}
+		}
+		parentOf = func(pid int) int {
+			return parents[pid]
+		}
+	}
+
+	pids := map[int]bool{os.Getpid(): true}
+	for pid := os.Getppid(); pid > 0 && !pids[pid]; pid = parentOf(pid) {
+		pids[pid] = true
+	}
+	return pids
+}
+
+// SendSignal sends a signal, given by name (TERM, SIGHUP) or number, to a process
+func SendSignal(pid int, signal string) error {
+	sig, err := ParseSignal(signal)
+	if err != nil {
+		return err
+	}
+
+	return describeControlError(sendSignal(pid, sig))
+}
+
+// TerminateProcess sends SIGTERM and waits up to timeout for the process to exit,
+// then sends SIGKILL. It reports whether the process had to be killed.
+func TerminateProcess(pid int, timeout time.Duration) (bool, error) {
+	if err := sendSignal(pid, syscall.SIGTERM); err != nil {
+		return false, describeControlError(err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if !processAlive(pid) {
+			return false, nil
+		}
+		time.Sleep(terminatePollInterval)
+	}
+
+	if !processAlive(pid) {
+		return false, nil
+	}
+
+	if err := sendSignal(pid, syscall.SIGKILL); err != nil {
+		return false, describeControlError(err)
+	}
+	return true, nil
+}
+
+// ValidateNice checks a nice value (-20 highest to 19 lowest priority)
+func ValidateNice(nice int) error {
+	if nice < -20 || nice > 19 {
+		return fmt.Errorf("nice value %d is out of range (-20 to 19)", nice)
+	}
+	return nil
+}
+
+// ReniceProcess sets the nice value of a process
+func ReniceProcess(pid, nice int) error {
+	if err := ValidateNice(nice); err != nil {
+		return err
+	}
+
+	return describeControlError(setNice(pid, nice))
+}
+
+// ParseIOPriority checks an I/O scheduling class and level (0 highest to 7 lowest) and
+// returns the class's ioprio_set(2) value
+func ParseIOPriority(class string, level int) (int, error) {
+	classValue, ok := ioPriorityClasses[strings.ToLower(class)]
+	if !ok {
+		return 0, fmt.Errorf("unknown I/O class '%s' (use none, realtime, best-effort or idle)", class)
+	}
+	if level < 0 || level > 7 {
+		return 0, fmt.Errorf("I/O priority level %d is out of range (0 to 7)", level)
+	}
+	return classValue, nil
+}
+
+// SetIOPriority sets the I/O scheduling class and level of a process
+func SetIOPriority(pid int, class string, level int) error {
+	classValue, err := ParseIOPriority(class, level)
+	if err != nil {
+		return err
+	}
+
+	return describeControlError(setIOPriority(pid, classValue, level))
+}
+
+// SetCPUAffinity restricts a process to the given CPUs
+func SetCPUAffinity(pid int, cpus []int) error {
+	if len(cpus) == 0 {
+		return fmt.Errorf("no CPUs given")
+	}
+	for _, cpu := range cpus {
+		if cpu >= runtime.NumCPU() {
+			return fmt.Errorf("CPU %d does not exist (this system has %d)", cpu, runtime.NumCPU())
+		}
+	}
+
+	return describeControlError(setCPUAffinity(pid, cpus))
+}
+
+// ParseCPUList parses CPU lists such as "0-3,6" in the format used by taskset and cpusets
+func ParseCPUList(value string) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		bounds := strings.SplitN(part, "-", 2)
+		start, err := strconv.Atoi(bounds[0])
+		if err != nil || start < 0 {
+			return nil, fmt.Errorf("'%s' is not a valid CPU", part)
+		}
+
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.Atoi(bounds[1])
+			if err != nil || end < start {
+				return nil, fmt.Errorf("'%s' is not a valid CPU range", part)
+			}
+		}
+
+		// Checked before expanding, huge ranges would never finish
+		if end >= runtime.NumCPU() {
+			return nil, fmt.Errorf("CPU %d does not exist (this system has %d)", end, runtime.NumCPU())
+		}
+
+		for cpu := start; cpu <= end; cpu++ {
+			seen[cpu] = true
+		}
+	}
+
+	cpus := make([]int, 0, len(seen))
+	for cpu := range seen {
+		cpus = append(cpus, cpu)
+	}
+	sort.Ints(cpus)
+
+	if len(cpus) == 0 {
+		return nil, fmt.Errorf("empty CPU list")
+	}
+	return cpus, nil
+}
+
+// describeControlError adds a hint to permission errors, the most common reason actions fail
+func describeControlError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, os.ErrPermission):
+		return fmt.Errorf("permission denied (run as root or as the process owner): %w", err)
+	case errors.Is(err, syscall.ESRCH):
+		return fmt.Errorf("process no longer exists: %w", err)
+	}
+	return err
+}
@@ -0,0 +1,35 @@
+package collectors
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// ioprio_set(2) constants
+const (
+	ioprioWhoProcess = 1
+	ioprioClassShift = 13
+)
+
+func setIOPriority(pid, class, level int) error {
+	ioprio := class<<ioprioClassShift | level
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(pid), uintptr(ioprio)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+func setCPUAffinity(pid int, cpus []int) error {
+	// Room for 1024 CPUs, the size of the kernel's default cpu_set_t
+	var mask [16]uint64
+	for _, cpu := range cpus {
+		if cpu/64 < len(mask) {
+			mask[cpu/64] |= 1 << (uint(cpu) % 64)
+		}
+	}
+
+	if _, _, errno := syscall.RawSyscall(syscall.SYS_SCHED_SETAFFINITY, uintptr(pid), unsafe.Sizeof(mask), uintptr(unsafe.Pointer(&mask[0]))); errno != 0 {
+		return errno
+	}
+	return nil
+}
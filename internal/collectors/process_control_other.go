@@ -0,0 +1,17 @@
+//go:build !linux
+
+package collectors
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// I/O priorities and CPU affinity are only implemented on Linux
+func setIOPriority(pid, class, level int) error {
+	return fmt.Errorf("ionice is not supported on %s", runtime.GOOS)
+}
+
+func setCPUAffinity(pid int, cpus []int) error {
+	return fmt.Errorf("CPU affinity is not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,64 @@
+//go:build !windows
+
+package collectors
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// Signals accepted by name, without the SIG prefix
+var signalNames = map[string]syscall.Signal{
+	"HUP":  syscall.SIGHUP,
+	"INT":  syscall.SIGINT,
+	"QUIT": syscall.SIGQUIT,
+	"KILL": syscall.SIGKILL,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"TERM": syscall.SIGTERM,
+	"CONT": syscall.SIGCONT,
+	"STOP": syscall.SIGSTOP,
+	"TSTP": syscall.SIGTSTP,
+}
+
+// ParseSignal converts a signal name (TERM, SIGTERM) or number into a signal
+func ParseSignal(name string) (syscall.Signal, error) {
+	if number, err := strconv.Atoi(name); err == nil {
+		if number <= 0 || number > 64 {
+			return 0, fmt.Errorf("signal %d is out of range", number)
+		}
+		return syscall.Signal(number), nil
+	}
+
+	sig, ok := signalNames[strings.TrimPrefix(strings.ToUpper(name), "SIG")]
+	if !ok {
+		return 0, fmt.Errorf("unknown signal '%s'", name)
+	}
+	return sig, nil
+}
+
+func sendSignal(pid int, sig syscall.Signal) error {
+	return syscall.Kill(pid, sig)
+}
+
+// processAlive reports whether a process still exists. Zombies count as exited
+// since they only wait for their parent to reap them.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err == syscall.ESRCH {
+		return false
+	}
+
+	if runtime.GOOS == "linux" {
+		if stat, err := readProcStat(pid); err != nil || stat.State == "Z" {
+			return false
+		}
+	}
+	return true
+}
+
+func setNice(pid, nice int) error {
+	return syscall.Setpriority(syscall.PRIO_PROCESS, pid, nice)
+}
@@ -0,0 +1,59 @@
+package collectors
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+)
+
+// ParseSignal converts a signal name into a signal. Windows can only terminate processes,
+// so TERM and KILL are the only signals accepted.
+func ParseSignal(name string) (syscall.Signal, error) {
+	switch strings.TrimPrefix(strings.ToUpper(name), "SIG") {
+	case "TERM", "15":
+		return syscall.SIGTERM, nil
+	case "KILL", "9":
+		return syscall.SIGKILL, nil
+	}
+	return 0, fmt.Errorf("signal '%s' is not supported on windows (use TERM or KILL)", name)
+}
+
+// Rights needed to check whether a process is still running
+const processQueryLimitedInformation = 0x1000
+
+// Exit code GetExitCodeProcess reports while a process is still running
+const stillActive = 259
+
+// sendSignal terminates the process for TERM and KILL. Windows has no other signals.
+func sendSignal(pid int, sig syscall.Signal) error {
+	if sig != syscall.SIGTERM && sig != syscall.SIGKILL {
+		return fmt.Errorf("signal %d is not supported on windows (use TERM or KILL)", int(sig))
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
+
+// processAlive reports whether a process is still running. os.FindProcess can't tell,
+// it succeeds for processes that have exited as long as a handle to them is open.
+func processAlive(pid int) bool {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		return false
+	}
+	defer syscall.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := syscall.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
+}
+
+func setNice(pid, nice int) error {
+	return fmt.Errorf("renice is not supported on windows")
+}
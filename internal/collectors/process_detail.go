@@ -0,0 +1,405 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// GetProcessDetailInfo displays everything whosay knows about a single process
+func GetProcessDetailInfo(pid int, showEnv bool, opts models.Options) {
+	detail, err := GetProcessDetail(pid, showEnv)
+	if err != nil {
+		fmt.Printf("Error getting process details: %v\n", err)
+		return
+	}
+
+	if opts.JSONOutput {
+		jsonData, err := json.MarshalIndent(detail, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing process details: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	sections := GetProcessDetailSections(detail, opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetProcessDetail collects the detail view of a process. The environment is
+// only read when requested since it commonly holds secrets.
+func GetProcessDetail(pid int, showEnv bool) (models.ProcessDetail, error) {
+	if runtime.GOOS == "linux" {
+		return getLinuxProcessDetail(pid, showEnv)
+	}
+
+	processes, err := GetTopProcesses(0)
+	if err != nil {
+		return models.ProcessDetail{}, err
+	}
+
+	detail := models.ProcessDetail{}
+	found := false
+	for _, proc := range processes {
+		if proc.PID == pid {
+			detail.ProcessInfo = proc
+			found = true
+		} else if proc.PPID == pid && pid != 0 {
+			detail.Children = append(detail.Children, proc)
+		}
+	}
+
+	if !found {
+		return models.ProcessDetail{}, fmt.Errorf("no process with PID %d", pid)
+	}
+
+	sortProcesses(detail.Children, "pid", true)
+	detail.Unavailable = append(detail.Unavailable, "details (only supported on Linux)")
+	return detail, nil
+}
+
+// getLinuxProcessDetail reads a process and its children from /proc. Only they are
+// sampled for CPU usage, instead of every process like the process list does.
+func getLinuxProcessDetail(pid int, showEnv bool) (models.ProcessDetail, error) {
+	stat, err := readProcStat(pid)
+	if err != nil {
+		return models.ProcessDetail{}, fmt.Errorf("no process with PID %d", pid)
+	}
+
+	children, err := readLinuxChildStats(pid)
+	if err != nil {
+		return models.ProcessDetail{}, fmt.Errorf("failed to read /proc: %w", err)
+	}
+	sampled := append([]procStat{stat}, children...)
+
+	before := procJiffies(sampled)
+	sampledAt := time.Now()
+	time.Sleep(processSampleInterval)
+	elapsed := time.Since(sampledAt).Seconds()
+
+	var memTotal uint64
+	if meminfo, err := readMemInfo(); err == nil {
+		memTotal = meminfo["MemTotal"]
+	}
+	pageSize := uint64(os.Getpagesize())
+
+	detail := models.ProcessDetail{}
+	for i, previous := range sampled {
+		// Processes that exit while sampled keep their first reading
+		current, err := readProcStat(previous.PID)
+		if err != nil || current.StartTicks != previous.StartTicks {
+			current = previous
+		}
+
+		proc := linuxProcessInfo(current, memTotal, pageSize)
+		key := procKey{PID: current.PID, StartTicks: current.StartTicks}
+		ticks := counterDelta(before[key], current.UTime+current.STime)
+		proc.CPU = float64(ticks) / clockTicksPerSecond / elapsed * 100
+
+		if i == 0 {
+			detail.ProcessInfo = proc
+		} else {
+			detail.Children = append(detail.Children, proc)
+		}
+	}
+
+	sortProcesses(detail.Children, "pid", true)
+	collectLinuxProcessDetail(&detail, showEnv)
+	return detail, nil
+}
+
+// readLinuxChildStats reads the stat of each child of a process. Without the kernel's
+// children lists, every process in /proc is read to find them.
+func readLinuxChildStats(pid int) ([]procStat, error) {
+	children := []procStat{}
+
+	pids, err := readProcChildren(pid)
+	if err == nil {
+		for _, child := range pids {
+			// Children can exit before they are read
+			if stat, err := readProcStat(child); err == nil && stat.PPID == pid {
+				children = append(children, stat)
+			}
+		}
+		return children, nil
+	}
+
+	stats, err := readAllProcStats()
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range stats {
+		if other.PPID == pid && other.PID != pid {
+			children = append(children, other)
+		}
+	}
+	return children, nil
+}
+
+// collectLinuxProcessDetail fills the detail fields from /proc/[pid].
+// Files that can't be read, usually for lack of permission, are listed in Unavailable.
+func collectLinuxProcessDetail(detail *models.ProcessDetail, showEnv bool) {
+	pid := detail.PID
+	unavailable := func(field string) {
+		detail.Unavailable = append(detail.Unavailable, field)
+	}
+
+	if stat, err := readProcStat(pid); err == nil {
+		detail.Nice = stat.Nice
+	}
+
+	if exe, err := os.Readlink(procPath(pid, "exe")); err == nil {
+		detail.Exe = exe
+	} else if !strings.HasPrefix(detail.CommandLine, "[") { // Kernel threads have no executable
+		unavailable("exe")
+	}
+
+	if cwd, err := os.Readlink(procPath(pid, "cwd")); err == nil {
+		detail.Cwd = cwd
+	} else {
+		unavailable("cwd")
+	}
+
+	if fds, err := countOpenFDs(pid); err == nil {
+		detail.OpenFDs = fds
+		detail.Sockets, _ = countSockets(pid)
+	} else {
+		unavailable("open_fds")
+	}
+
+	if limits, err := readProcLimits(pid); err == nil {
+		detail.Limits = limits
+		for _, limit := range limits {
+			if limit.Name == "Max open files" {
+				detail.FDLimit, _ = strconv.ParseUint(limit.Soft, 10, 64)
+			}
+		}
+	} else {
+		unavailable("limits")
+	}
+
+	// smaps_rollup needs Linux 4.14, fall back to the totals in status
+	if values, err := readProcKeyValues(procPath(pid, "smaps_rollup")); err == nil {
+		detail.MemoryMaps = models.ProcessMemoryMaps{
+			RSS:          values["Rss"],
+			PSS:          values["Pss"],
+			SharedClean:  values["Shared_Clean"],
+			SharedDirty:  values["Shared_Dirty"],
+			PrivateClean: values["Private_Clean"],
+			PrivateDirty: values["Private_Dirty"],
+			Swap:         values["Swap"],
+		}
+	} else if values, err := readProcKeyValues(procPath(pid, "status")); err == nil {
+		detail.MemoryMaps = models.ProcessMemoryMaps{
+			RSS:  values["VmRSS"],
+			Swap: values["VmSwap"],
+		}
+	} else {
+		unavailable("memory_maps")
+	}
+
+	if io, err := readProcIO(pid); err == nil {
+		detail.IO = &io
+	} else {
+		unavailable("io")
+	}
+
+	if cgroup, err := readProcCgroup(pid); err == nil {
+		detail.Cgroup = cgroup
+	}
+
+	if namespaces, err := readProcNamespaces(pid); err == nil && len(namespaces) > 0 {
+		detail.Namespaces = namespaces
+	} else {
+		unavailable("namespaces")
+	}
+
+	if showEnv {
+		if env, err := readProcEnviron(pid); err == nil {
+			detail.Environment = env
+		} else {
+			unavailable("environment")
+		}
+	}
+}
+
+// GetProcessDetailSections formats the process detail view for the compact display
+func GetProcessDetailSections(detail models.ProcessDetail, opts models.Options) map[string][][]string {
+	overview := [][]string{
+		{"PID", fmt.Sprintf("%d", detail.PID)},
+		{"Name", detail.Name},
+		{"State", fmt.Sprintf("%s (%s)", describeProcessState(detail.Status), detail.Status)},
+		{"Parent", fmt.Sprintf("%d", detail.PPID)},
+		{"User", detail.User},
+		{"Started", formatStartTime(detail.StartTime)},
+		{"CPU", fmt.Sprintf("%.1f%%", detail.CPU)},
+		{"Threads", fmt.Sprintf("%d", detail.Threads)},
+		{"Nice", fmt.Sprintf("%d", detail.Nice)},
+		{"Command", detail.CommandLine},
+	}
+
+	if detail.Exe != "" {
+		overview = append(overview, []string{"Executable", detail.Exe})
+	}
+	if detail.Cwd != "" {
+		overview = append(overview, []string{"Working Dir", detail.Cwd})
+	}
+	if detail.Cgroup != "" {
+		overview = append(overview, []string{"Cgroup", detail.Cgroup})
+	}
+	if len(detail.Unavailable) > 0 {
+		overview = append(overview, []string{"Unavailable", strings.Join(detail.Unavailable, ", ")})
+	}
+
+	barWidth := 20
+	if opts.CompactMode {
+		barWidth = 15
+	}
+
+	maps := detail.MemoryMaps
+	rss := maps.RSS
+	if rss == 0 {
+		rss = detail.MemoryRSS * 1024
+	}
+	memoryData := [][]string{
+		{"RSS", formatBytes(rss)},
+	}
+	if maps.PSS > 0 {
+		memoryData = append(memoryData,
+			[]string{"PSS", formatBytes(maps.PSS)},
+			[]string{"Private", formatBytes(maps.PrivateClean + maps.PrivateDirty)},
+			[]string{"Shared", formatBytes(maps.SharedClean + maps.SharedDirty)},
+		)
+	}
+	memoryData = append(memoryData,
+		[]string{"Swap", formatBytes(maps.Swap)},
+		[]string{"", ui.PrintCompactUsageBar("", detail.Memory, barWidth)},
+	)
+
+	result := map[string][][]string{
+		"Process Detail": overview,
+		"Process Memory": memoryData,
+	}
+
+	if runtime.GOOS != "linux" {
+		return result
+	}
+
+	// Counters of other users' processes need root, zeros would look like an idle process
+	if io := detail.IO; io != nil {
+		result["Process I/O"] = [][]string{
+			{"Read", formatBytes(io.ReadBytes)},
+			{"Written", formatBytes(io.WriteBytes)},
+			{"Cancelled", formatBytes(io.CancelledWriteBytes)},
+			{"Chars", fmt.Sprintf("%s read, %s written", formatBytes(io.ReadChars), formatBytes(io.WriteChars))},
+			{"Syscalls", fmt.Sprintf("%d read, %d write", io.ReadSyscalls, io.WriteSyscalls)},
+		}
+	} else {
+		result["Process I/O"] = [][]string{
+			{"Read", "-"},
+			{"Written", "-"},
+			{"Cancelled", "-"},
+			{"Chars", "-"},
+			{"Syscalls", "-"},
+		}
+	}
+
+	filesData := [][]string{
+		{"Open FDs", fmt.Sprintf("%d", detail.OpenFDs)},
+		{"Sockets", fmt.Sprintf("%d", detail.Sockets)},
+	}
+	if detail.FDLimit > 0 {
+		filesData = append(filesData,
+			[]string{"Limit", fmt.Sprintf("%d", detail.FDLimit)},
+			[]string{"", ui.PrintCompactUsageBar("", float64(detail.OpenFDs)/float64(detail.FDLimit)*100, barWidth)},
+		)
+	}
+	result["Process Files"] = filesData
+
+	// The full limits table is long, only show it on request
+	if opts.VerboseOutput && len(detail.Limits) > 0 {
+		limitsData := [][]string{{"Limit", "Soft", "Hard", "Units"}}
+		for _, limit := range detail.Limits {
+			limitsData = append(limitsData, []string{limit.Name, limit.Soft, limit.Hard, limit.Units})
+		}
+		result["Process Limits"] = limitsData
+	}
+
+	if len(detail.Namespaces) > 0 {
+		names := make([]string, 0, len(detail.Namespaces))
+		for name := range detail.Namespaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		nsData := [][]string{}
+		for _, name := range names {
+			nsData = append(nsData, []string{name, detail.Namespaces[name]})
+		}
+		result["Process Namespaces"] = nsData
+	}
+
+	if len(detail.Children) > 0 {
+		childData := [][]string{{"PID", "Name", "CPU%", "Memory%"}}
+		for _, child := range detail.Children {
+			childData = append(childData, []string{
+				fmt.Sprintf("%d", child.PID),
+				child.Name,
+				fmt.Sprintf("%.1f", child.CPU),
+				fmt.Sprintf("%.1f", child.Memory),
+			})
+		}
+		result["Process Children"] = childData
+	}
+
+	if len(detail.Environment) > 0 {
+		envData := [][]string{}
+		for _, entry := range detail.Environment {
+			key, value, _ := strings.Cut(entry, "=")
+			envData = append(envData, []string{key, value})
+		}
+		result["Process Environment"] = envData
+	}
+
+	return result
+}
+
+// describeProcessState expands the single letter state codes of /proc/[pid]/stat
+func describeProcessState(state string) string {
+	switch state {
+	case "R":
+		return "Running"
+	case "S":
+		return "Sleeping"
+	case "D":
+		return "Uninterruptible sleep"
+	case "Z":
+		return "Zombie"
+	case "T":
+		return "Stopped"
+	case "t":
+		return "Tracing stop"
+	case "X", "x":
+		return "Dead"
+	case "I":
+		return "Idle"
+	case "W":
+		return "Paging"
+	case "K":
+		return "Wakekill"
+	case "P":
+		return "Parked"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,227 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Fields processes can be aggregated by
+var processGroupKeys = []string{"user", "exe", "cgroup"}
+
+// Sort keys that apply to process groups
+var processGroupSortKeys = []string{"cpu", "mem", "memory", "rss", "threads", "name"}
+
+// isProcessGroupSortKey reports whether process groups can be ranked by the key
+func isProcessGroupSortKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, candidate := range processGroupSortKeys {
+		if candidate == key {
+			return true
+		}
+	}
+	return false
+}
+
+// isProcessGroupKey reports whether the key is a supported aggregation field
+func isProcessGroupKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, candidate := range processGroupKeys {
+		if candidate == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetProcessGroupInfo displays resource usage summed per user, executable or cgroup
+func GetProcessGroupInfo(opts models.Options) {
+	processes, err := GetTopProcesses(0)
+	if err != nil {
+		fmt.Printf("Error getting process information: %v\n", err)
+		return
+	}
+
+	if opts.JSONOutput {
+		aggregate := models.ProcessAggregate{
+			GroupBy: strings.ToLower(opts.Process.GroupBy),
+			Groups:  SelectProcessGroups(processes, opts.Process),
+		}
+		jsonData, err := json.MarshalIndent(aggregate, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing process groups: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	sections := GetProcessGroupSections(processes, opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetProcessGroupSections formats the aggregated process groups as a ranked table
+func GetProcessGroupSections(processes []models.ProcessInfo, opts models.Options) map[string][][]string {
+	groupBy := strings.ToLower(opts.Process.GroupBy)
+	groups := SelectProcessGroups(processes, opts.Process)
+
+	groupTitle := map[string]string{
+		"user":   "User",
+		"exe":    "Executable",
+		"cgroup": "Cgroup",
+	}[groupBy]
+
+	header := []string{"#", groupTitle, "Procs", "Threads", "CPU%", "Mem%", "RSS"}
+	if groupBy != "user" {
+		header = append(header, "Users")
+	}
+	groupData := [][]string{header}
+
+	for i, group := range groups {
+		key := group.Key
+		if len(key) > 40 {
+			key = "..." + key[len(key)-37:] // Keep the most specific end of long cgroup paths
+		}
+
+		row := []string{
+			fmt.Sprintf("%d", i+1),
+			key,
+			fmt.Sprintf("%d", group.Processes),
+			fmt.Sprintf("%d", group.Threads),
+			ui.FormatPercent(group.CPU),
+			fmt.Sprintf("%.1f", group.Memory),
+			formatBytes(group.MemoryRSS * 1024),
+		}
+		if groupBy != "user" {
+			row = append(row, fmt.Sprintf("%d", group.Users))
+		}
+		groupData = append(groupData, row)
+	}
+
+	processData := [][]string{
+		{"Count", fmt.Sprintf("%d", len(processes))},
+		{"Grouped by", groupBy},
+		{"Groups", fmt.Sprintf("%d", len(groups))},
+	}
+
+	return map[string][][]string{
+		"Processes":      processData,
+		"Process Groups": groupData,
+	}
+}
+
+// SelectProcessGroups aggregates the processes matching the display filters and
+// ranks the groups by the sort key, limited as requested
+func SelectProcessGroups(processes []models.ProcessInfo, display models.ProcessDisplay) []models.ProcessGroup {
+	groups := AggregateProcesses(filterProcessDisplay(processes, display), display.GroupBy)
+	sortProcessGroups(groups, display.SortBy, display.Ascending)
+
+	if display.Limit > 0 && len(groups) > display.Limit {
+		groups = groups[:display.Limit]
+	}
+	return groups
+}
+
+// AggregateProcesses sums process counts, threads, CPU and memory per user, executable or cgroup
+func AggregateProcesses(processes []models.ProcessInfo, groupBy string) []models.ProcessGroup {
+	groupBy = strings.ToLower(groupBy)
+
+	groups := make(map[string]*models.ProcessGroup)
+	users := make(map[string]map[string]bool)
+	order := []string{}
+
+	for _, proc := range processes {
+		key := processGroupKey(proc, groupBy)
+
+		group, ok := groups[key]
+		if !ok {
+			group = &models.ProcessGroup{Key: key}
+			groups[key] = group
+			users[key] = make(map[string]bool)
+			order = append(order, key)
+		}
+
+		group.Processes++
+		group.Threads += proc.Threads
+		group.CPU += proc.CPU
+		group.Memory += proc.Memory
+		group.MemoryRSS += proc.MemoryRSS
+		users[key][proc.User] = true
+	}
+
+	result := make([]models.ProcessGroup, 0, len(order))
+	for _, key := range order {
+		group := *groups[key]
+		group.Users = len(users[key])
+		result = append(result, group)
+	}
+
+	return result
+}
+
+// processGroupKey returns the value a process is grouped under
+func processGroupKey(proc models.ProcessInfo, groupBy string) string {
+	switch groupBy {
+	case "user":
+		if proc.User != "" {
+			return proc.User
+		}
+	case "exe":
+		// Kernel threads share names like kworker/0:1, group them by their base name
+		if strings.HasPrefix(proc.CommandLine, "[") {
+			if base, _, found := strings.Cut(proc.Name, "/"); found {
+				return "[" + base + "]"
+			}
+			return "[" + proc.Name + "]"
+		}
+		// Names are cut to 15 characters, the executable path tells programs apart.
+		// Other users' executables can only be resolved by root. Binaries replaced by an
+		// upgrade while running read as "path (deleted)", they still belong with the new one.
+		if exe, err := os.Readlink(procPath(proc.PID, "exe")); err == nil && exe != "" {
+			return strings.TrimSuffix(exe, " (deleted)")
+		}
+		if proc.Name != "" {
+			return proc.Name
+		}
+	case "cgroup":
+		if cgroup, err := readProcCgroup(proc.PID); err == nil {
+			return cgroup
+		}
+	}
+
+	return "unknown"
+}
+
+// sortProcessGroups ranks groups by the process sort key; CPU is the default
+func sortProcessGroups(groups []models.ProcessGroup, sortBy string, ascending bool) {
+	less := func(i, j int) bool {
+		return groups[i].CPU < groups[j].CPU
+	}
+
+	switch strings.ToLower(sortBy) {
+	case "mem", "memory", "rss":
+		less = func(i, j int) bool {
+			return groups[i].MemoryRSS < groups[j].MemoryRSS
+		}
+	case "threads":
+		less = func(i, j int) bool {
+			return groups[i].Threads < groups[j].Threads
+		}
+	case "name":
+		less = func(i, j int) bool {
+			return groups[i].Key < groups[j].Key
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if ascending {
+			return less(i, j)
+		}
+		return less(j, i)
+	})
+}
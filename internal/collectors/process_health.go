@@ -0,0 +1,297 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Default time a process may spend in uninterruptible sleep before it counts as stuck
+const DefaultStuckAfter = 30 * time.Second
+
+// healthKey identifies a process across watch ticks, guarding against PID reuse
+type healthKey struct {
+	PID   int
+	Start int64
+}
+
+// Process health state, kept across watch mode ticks
+var (
+	uninterruptibleSince = make(map[healthKey]time.Time)
+	alertedIssues        = make(map[string]bool)
+	processHealthMu      sync.Mutex
+)
+
+// GetProcessHealthInfo displays zombie, stuck and file descriptor problems
+func GetProcessHealthInfo(opts models.Options) {
+	health, err := CheckProcessHealth(opts)
+	if err != nil {
+		fmt.Printf("Error checking process health: %v\n", err)
+		return
+	}
+
+	if opts.JSONOutput {
+		jsonData, err := json.MarshalIndent(health, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing process health: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	sections := GetProcessHealthSections(health, opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetProcessHealthSections formats the process health report for the compact display
+func GetProcessHealthSections(health models.ProcessHealth, opts models.Options) map[string][][]string {
+	stuckAfter := opts.Process.StuckAfter
+	if stuckAfter <= 0 {
+		stuckAfter = DefaultStuckAfter
+	}
+
+	summary := [][]string{
+		{"Checked", fmt.Sprintf("%d processes", health.Checked)},
+		{"Zombies", countStatus(health.Zombies)},
+		{"D State", fmt.Sprintf("%d now, %s stuck > %s", health.Uninterruptible, countStatus(health.Stuck), stuckAfter)},
+		{"FD Pressure", countStatus(health.NearFDLimit)},
+	}
+
+	// Stuck processes can only be told apart from brief waits across samples
+	if !opts.InWatchMode && health.Uninterruptible > 0 && health.Stuck == 0 {
+		summary = append(summary, []string{"Note", "Use -watch to track how long processes stay in D state"})
+	}
+
+	result := map[string][][]string{
+		"Process Health": summary,
+	}
+
+	if len(health.Issues) > 0 {
+		issues := [][]string{
+			{"Issue", "PID", "Name", "Detail"},
+		}
+		for _, issue := range health.Issues {
+			issues = append(issues, []string{
+				issueLabel(issue.Type),
+				fmt.Sprintf("%d", issue.PID),
+				issue.Name,
+				issue.Detail,
+			})
+		}
+		result["Process Issues"] = issues
+	}
+
+	return result
+}
+
+// CheckProcessHealth looks for zombies, processes stuck in uninterruptible sleep and
+// processes close to their open file limit. New issues raise alerts when alerts are enabled.
+func CheckProcessHealth(opts models.Options) (models.ProcessHealth, error) {
+	processes, err := GetTopProcesses(0)
+	if err != nil {
+		return models.ProcessHealth{}, err
+	}
+
+	stuckAfter := opts.Process.StuckAfter
+	if stuckAfter <= 0 {
+		stuckAfter = DefaultStuckAfter
+	}
+
+	processHealthMu.Lock()
+	defer processHealthMu.Unlock()
+
+	byPID := make(map[int]models.ProcessInfo, len(processes))
+	for _, proc := range processes {
+		byPID[proc.PID] = proc
+	}
+
+	now := time.Now()
+	health := models.ProcessHealth{
+		Checked: len(processes),
+		Issues:  []models.ProcessIssue{},
+	}
+	seen := make(map[healthKey]bool)
+
+	for _, proc := range processes {
+		key := healthKey{PID: proc.PID, Start: proc.StartTime.UnixNano()}
+
+		switch proc.Status {
+		case "Z":
+			parent := byPID[proc.PPID]
+			health.Zombies++
+			health.Issues = append(health.Issues, models.ProcessIssue{
+				Type:       "zombie",
+				PID:        proc.PID,
+				Name:       proc.Name,
+				PPID:       proc.PPID,
+				ParentName: parent.Name,
+				Detail:     fmt.Sprintf("not reaped by parent %d (%s)", proc.PPID, parent.Name),
+			})
+		case "D":
+			health.Uninterruptible++
+			seen[key] = true
+			since, ok := uninterruptibleSince[key]
+			if !ok {
+				since = now
+				uninterruptibleSince[key] = now
+			}
+
+			if stuck := now.Sub(since); stuck >= stuckAfter {
+				health.Stuck++
+				health.Issues = append(health.Issues, models.ProcessIssue{
+					Type:     "stuck",
+					PID:      proc.PID,
+					Name:     proc.Name,
+					PPID:     proc.PPID,
+					Duration: stuck.Seconds(),
+					Detail:   fmt.Sprintf("uninterruptible sleep for %s", stuck.Round(time.Second)),
+				})
+			}
+		}
+
+		if issue, ok := checkFDPressure(proc); ok {
+			health.NearFDLimit++
+			health.Issues = append(health.Issues, issue)
+		}
+	}
+
+	// Forget processes that left D state or exited
+	for key := range uninterruptibleSince {
+		if !seen[key] {
+			delete(uninterruptibleSince, key)
+		}
+	}
+
+	sort.SliceStable(health.Issues, func(i, j int) bool {
+		return issueRank(health.Issues[i].Type) < issueRank(health.Issues[j].Type)
+	})
+
+	// Alerts print to stdout, where they would break the JSON document
+	if opts.EnableAlerts && !opts.JSONOutput {
+		raiseProcessAlerts(health.Issues, stuckAfter)
+	}
+
+	return health, nil
+}
+
+// checkFDPressure reports a process using most of its open file soft limit
+func checkFDPressure(proc models.ProcessInfo) (models.ProcessIssue, bool) {
+	// Descriptor counts and limits come from /proc and are only readable for our own
+	// processes unless running as root
+	if runtime.GOOS != "linux" {
+		return models.ProcessIssue{}, false
+	}
+
+	open, err := countOpenFDs(proc.PID)
+	if err != nil || open == 0 {
+		return models.ProcessIssue{}, false
+	}
+
+	limit := openFileLimit(proc.PID)
+	if limit == 0 {
+		return models.ProcessIssue{}, false
+	}
+
+	// Processes are reported from the same threshold the alert uses
+	usage := float64(open) / float64(limit) * 100
+	if usage < alertManager.Thresholds.FDWarning {
+		return models.ProcessIssue{}, false
+	}
+
+	return models.ProcessIssue{
+		Type:    "fd_limit",
+		PID:     proc.PID,
+		Name:    proc.Name,
+		PPID:    proc.PPID,
+		OpenFDs: open,
+		FDLimit: limit,
+		Detail:  fmt.Sprintf("%d of %d file descriptors open (%.0f%%)", open, limit, usage),
+	}, true
+}
+
+// openFileLimit returns the RLIMIT_NOFILE soft limit of a process, or 0 if unknown or unlimited
+func openFileLimit(pid int) uint64 {
+	limits, err := readProcLimits(pid)
+	if err != nil {
+		return 0
+	}
+
+	for _, limit := range limits {
+		if limit.Name == "Max open files" {
+			value, _ := strconv.ParseUint(limit.Soft, 10, 64)
+			return value
+		}
+	}
+	return 0
+}
+
+// raiseProcessAlerts sends each issue to the alert manager once, for as long as it persists
+func raiseProcessAlerts(issues []models.ProcessIssue, stuckAfter time.Duration) {
+	current := make(map[string]bool, len(issues))
+
+	for _, issue := range issues {
+		id := fmt.Sprintf("%s:%d", issue.Type, issue.PID)
+		current[id] = true
+		if alertedIssues[id] {
+			continue
+		}
+		alertedIssues[id] = true
+
+		switch issue.Type {
+		case "zombie":
+			alertManager.CheckZombie(issue.PID, issue.Name, issue.PPID, issue.ParentName)
+		case "stuck":
+			alertManager.CheckStuckProcess(issue.PID, issue.Name, time.Duration(issue.Duration*float64(time.Second)), stuckAfter)
+		case "fd_limit":
+			alertManager.CheckFileDescriptors(issue.PID, issue.Name, issue.OpenFDs, issue.FDLimit)
+		}
+	}
+
+	// Resolved issues alert again if they come back
+	for id := range alertedIssues {
+		if !current[id] {
+			delete(alertedIssues, id)
+		}
+	}
+}
+
+// issueRank orders issues with the most urgent types first
+func issueRank(issueType string) int {
+	switch issueType {
+	case "stuck":
+		return 0
+	case "fd_limit":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// issueLabel returns the display name of an issue type
+func issueLabel(issueType string) string {
+	switch issueType {
+	case "zombie":
+		return "Zombie"
+	case "stuck":
+		return "Stuck (D)"
+	case "fd_limit":
+		return "FD limit"
+	}
+	return issueType
+}
+
+// countStatus formats an issue count, colored when non-zero
+func countStatus(count int) string {
+	if count == 0 {
+		return ui.SuccessColor("0")
+	}
+	return ui.WarningColor(fmt.Sprintf("%d", count))
+}
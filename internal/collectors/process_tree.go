@@ -0,0 +1,169 @@
+package collectors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// PID of kthreadd, the parent of all kernel threads on Linux
+const kernelThreadParentPID = 2
+
+// GetProcessTreeInfo displays running processes as a parent/child hierarchy
+func GetProcessTreeInfo(opts models.Options) {
+	processes, err := GetTopProcesses(0)
+	if err != nil {
+		fmt.Printf("Error getting process information: %v\n", err)
+		return
+	}
+
+	roots := BuildProcessTree(processes, getCollapsedPIDs(opts))
+
+	if opts.JSONOutput {
+		jsonData, err := json.MarshalIndent(roots, "", "  ")
+		if err != nil {
+			fmt.Printf("Error serializing process tree: %v\n", err)
+			return
+		}
+		fmt.Println(string(jsonData))
+		return
+	}
+
+	sections := GetProcessTreeSections(processes, opts)
+	ui.CompactDisplay(sections)
+}
+
+// GetProcessTreeSections formats the process hierarchy for the compact display
+func GetProcessTreeSections(processes []models.ProcessInfo, opts models.Options) map[string][][]string {
+	roots := BuildProcessTree(processes, getCollapsedPIDs(opts))
+
+	treeData := [][]string{
+		{"PID", "Process", "CPU%", "Mem%", "Tree CPU%", "Tree Mem%"},
+	}
+
+	for i, root := range roots {
+		treeData = appendTreeRows(treeData, root, "", i == len(roots)-1, true)
+	}
+
+	return map[string][][]string{
+		"Processes": {
+			{"Count", fmt.Sprintf("%d", len(processes))},
+		},
+		"Process Tree": treeData,
+	}
+}
+
+// BuildProcessTree links processes to their parents, rolls up resource usage
+// per subtree and returns the root nodes. Subtrees under collapsed PIDs are pruned.
+func BuildProcessTree(processes []models.ProcessInfo, collapsed map[int]bool) []*models.ProcessTreeNode {
+	nodes := make(map[int]*models.ProcessTreeNode, len(processes))
+	for _, proc := range processes {
+		nodes[proc.PID] = &models.ProcessTreeNode{ProcessInfo: proc}
+	}
+
+	roots := []*models.ProcessTreeNode{}
+	for _, proc := range processes {
+		node := nodes[proc.PID]
+
+		// Processes whose parent isn't visible (e.g. PID 1, or outside our PID namespace) are roots
+		parent, ok := nodes[proc.PPID]
+		if !ok || proc.PPID == proc.PID {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	sortTreeNodes(roots)
+	for _, root := range roots {
+		rollUpTree(root, collapsed)
+	}
+
+	return roots
+}
+
+// rollUpTree sums resource usage over a subtree and prunes collapsed children
+func rollUpTree(node *models.ProcessTreeNode, collapsed map[int]bool) {
+	node.TreeCPU = node.CPU
+	node.TreeMemory = node.Memory
+	node.TreeRSS = node.MemoryRSS
+	node.Descendants = 0
+
+	sortTreeNodes(node.Children)
+	for _, child := range node.Children {
+		rollUpTree(child, collapsed)
+
+		node.TreeCPU += child.TreeCPU
+		node.TreeMemory += child.TreeMemory
+		node.TreeRSS += child.TreeRSS
+		node.Descendants += child.Descendants + 1
+	}
+
+	if collapsed[node.PID] && len(node.Children) > 0 {
+		node.Collapsed = true
+		node.Children = nil
+	}
+}
+
+// sortTreeNodes orders sibling nodes by PID
+func sortTreeNodes(nodes []*models.ProcessTreeNode) {
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].PID < nodes[j].PID
+	})
+}
+
+// appendTreeRows renders a node and its children with box-drawing indentation
+func appendTreeRows(rows [][]string, node *models.ProcessTreeNode, prefix string, last, root bool) [][]string {
+	branch := ""
+	childPrefix := ""
+	if !root {
+		if last {
+			branch = prefix + "└─ "
+			childPrefix = prefix + "   "
+		} else {
+			branch = prefix + "├─ "
+			childPrefix = prefix + "│  "
+		}
+	}
+
+	name := node.Name
+	if len(name) > 25 {
+		name = name[:22] + "..."
+	}
+	if node.Collapsed {
+		name = fmt.Sprintf("%s [+%d]", name, node.Descendants)
+	}
+
+	rows = append(rows, []string{
+		fmt.Sprintf("%d", node.PID),
+		ui.DimColor(branch) + name,
+		fmt.Sprintf("%.1f", node.CPU),
+		fmt.Sprintf("%.1f", node.Memory),
+		ui.FormatPercent(node.TreeCPU),
+		ui.FormatPercent(node.TreeMemory),
+	})
+
+	for i, child := range node.Children {
+		rows = appendTreeRows(rows, child, childPrefix, i == len(node.Children)-1, false)
+	}
+
+	return rows
+}
+
+// getCollapsedPIDs returns the PIDs whose subtrees should be collapsed.
+// Kernel threads are collapsed unless verbose output is requested.
+func getCollapsedPIDs(opts models.Options) map[int]bool {
+	collapsed := make(map[int]bool)
+	for _, pid := range opts.Process.Collapse {
+		collapsed[pid] = true
+	}
+
+	if !opts.VerboseOutput {
+		collapsed[kernelThreadParentPID] = true
+	}
+
+	return collapsed
+}
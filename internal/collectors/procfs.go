@@ -0,0 +1,440 @@
+package collectors
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// Kernel USER_HZ, the unit of the tick counters in /proc; 100 on all mainstream architectures
+const clockTicksPerSecond = 100
+
+// procStat holds the fields of /proc/[pid]/stat used by whosay
+type procStat struct {
+	PID        int
+	Name       string
+	State      string
+	PPID       int
+	UTime      uint64
+	STime      uint64
+	Nice       int
+	Threads    int
+	StartTicks uint64
+	RSSPages   uint64
+}
+
+// Cached lookups that don't change while whosay runs
+var (
+	procBootTime  time.Time
+	usernameCache = make(map[string]string)
+	procCacheMu   sync.Mutex
+)
+
+// listPIDs returns the IDs of all processes currently in /proc
+func listPIDs() ([]int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	pids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if pid, err := strconv.Atoi(entry.Name()); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+
+	return pids, nil
+}
+
+// readProcStat parses /proc/[pid]/stat
+func readProcStat(pid int) (procStat, error) {
+	data, err := os.ReadFile(procPath(pid, "stat"))
+	if err != nil {
+		return procStat{}, err
+	}
+
+	// The command name is wrapped in parentheses and may itself contain spaces or parentheses
+	content := string(data)
+	nameStart := strings.IndexByte(content, '(')
+	nameEnd := strings.LastIndexByte(content, ')')
+	if nameStart < 0 || nameEnd < nameStart {
+		return procStat{}, fmt.Errorf("malformed stat for pid %d", pid)
+	}
+
+	// Fields after the name start at field 3 (state) of proc(5)
+	fields := strings.Fields(content[nameEnd+1:])
+	if len(fields) < 22 {
+		return procStat{}, fmt.Errorf("short stat for pid %d", pid)
+	}
+
+	field := func(n int) uint64 {
+		value, _ := strconv.ParseUint(fields[n-3], 10, 64)
+		return value
+	}
+
+	nice, _ := strconv.Atoi(fields[19-3])
+
+	return procStat{
+		PID:        pid,
+		Name:       content[nameStart+1 : nameEnd],
+		State:      fields[0],
+		PPID:       int(field(4)),
+		UTime:      field(14),
+		STime:      field(15),
+		Nice:       nice,
+		Threads:    int(field(20)),
+		StartTicks: field(22),
+		RSSPages:   field(24),
+	}, nil
+}
+
+// readProcStatus parses the "Key: value" lines of /proc/[pid]/status
+func readProcStatus(pid int) (map[string]string, error) {
+	file, err := os.Open(procPath(pid, "status"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) == 2 {
+			result[parts[0]] = strings.TrimSpace(parts[1])
+		}
+	}
+
+	return result, scanner.Err()
+}
+
+// readProcCmdline returns the NUL separated arguments of /proc/[pid]/cmdline joined by spaces
+func readProcCmdline(pid int) string {
+	data, err := os.ReadFile(procPath(pid, "cmdline"))
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.Join(strings.Split(strings.TrimRight(string(data), "\x00"), "\x00"), " "))
+}
+
+// readProcChildren returns the child PIDs listed in /proc/[pid]/task/*/children. Each
+// thread lists the children it forked. Kernels built without CONFIG_PROC_CHILDREN have
+// no such files, which is an error.
+func readProcChildren(pid int) ([]int, error) {
+	paths, err := filepath.Glob(procPath(pid, "task/*/children"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no children files for pid %d: %w", pid, os.ErrNotExist)
+	}
+
+	children := []int{}
+	seen := make(map[int]bool)
+	for _, path := range paths {
+		// Threads can exit between listing and reading
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		for _, field := range strings.Fields(string(data)) {
+			if child, err := strconv.Atoi(field); err == nil && !seen[child] {
+				seen[child] = true
+				children = append(children, child)
+			}
+		}
+	}
+
+	return children, nil
+}
+
+// statusUID returns the real user ID from parsed /proc/[pid]/status fields
+func statusUID(status map[string]string) string {
+	// Uid: real effective saved filesystem
+	fields := strings.Fields(status["Uid"])
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// lookupUsername resolves a user ID to a name, falling back to the numeric ID
+func lookupUsername(uid string) string {
+	if uid == "" {
+		return "?"
+	}
+
+	procCacheMu.Lock()
+	defer procCacheMu.Unlock()
+
+	if name, ok := usernameCache[uid]; ok {
+		return name
+	}
+
+	// Without cgo this reads /etc/passwd, which also works in minimal containers
+	name := uid
+	if u, err := user.LookupId(uid); err == nil {
+		name = u.Username
+	}
+	usernameCache[uid] = name
+
+	return name
+}
+
+// getBootTime returns the system boot time from the btime line of /proc/stat
+func getBootTime() time.Time {
+	procCacheMu.Lock()
+	defer procCacheMu.Unlock()
+
+	if !procBootTime.IsZero() {
+		return procBootTime
+	}
+
+	file, err := os.Open("/proc/stat")
+	if err != nil {
+		return time.Time{}
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "btime" {
+			if seconds, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				procBootTime = time.Unix(seconds, 0)
+			}
+			break
+		}
+	}
+
+	return procBootTime
+}
+
+// processStartTime converts the start time of a process from ticks after boot to wall time
+func processStartTime(startTicks uint64) time.Time {
+	boot := getBootTime()
+	if boot.IsZero() {
+		return time.Time{}
+	}
+
+	return boot.Add(time.Duration(startTicks) * time.Second / clockTicksPerSecond)
+}
+
+// procPath returns a path below /proc/[pid]
+func procPath(pid int, elem ...string) string {
+	return filepath.Join(append([]string{"/proc", strconv.Itoa(pid)}, elem...)...)
+}
+
+// readProcKeyValues parses "Key: value kB" style files such as smaps_rollup and io,
+// converting kB values to bytes
+func readProcKeyValues(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	result := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		parts := strings.SplitN(scanner.Text(), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		fields := strings.Fields(parts[1])
+		if len(fields) == 0 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		if len(fields) > 1 && fields[1] == "kB" {
+			value *= 1024
+		}
+		result[parts[0]] = value
+	}
+
+	return result, scanner.Err()
+}
+
+// readProcIO reads the I/O counters of /proc/[pid]/io, which requires ptrace access to the process
+func readProcIO(pid int) (models.ProcessIO, error) {
+	values, err := readProcKeyValues(procPath(pid, "io"))
+	if err != nil {
+		return models.ProcessIO{}, err
+	}
+
+	return models.ProcessIO{
+		ReadChars:           values["rchar"],
+		WriteChars:          values["wchar"],
+		ReadSyscalls:        values["syscr"],
+		WriteSyscalls:       values["syscw"],
+		ReadBytes:           values["read_bytes"],
+		WriteBytes:          values["write_bytes"],
+		CancelledWriteBytes: values["cancelled_write_bytes"],
+	}, nil
+}
+
+// readProcCgroup returns the most specific cgroup path of a process.
+// The unified (v2) path is preferred, falling back to the first non-root v1 controller path.
+func readProcCgroup(pid int) (string, error) {
+	data, err := os.ReadFile(procPath(pid, "cgroup"))
+	if err != nil {
+		return "", err
+	}
+
+	unified := ""
+	legacy := ""
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		// hierarchy-ID:controller-list:cgroup-path
+		parts := strings.SplitN(line, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		if parts[0] == "0" && parts[1] == "" {
+			unified = parts[2]
+		} else if legacy == "" && parts[2] != "/" {
+			legacy = parts[2]
+		}
+	}
+
+	switch {
+	case unified != "" && unified != "/":
+		return unified, nil
+	case legacy != "":
+		return legacy, nil
+	case unified != "":
+		return unified, nil
+	}
+
+	return "/", nil
+}
+
+// countOpenFDs counts the entries of /proc/[pid]/fd
+func countOpenFDs(pid int) (int, error) {
+	entries, err := os.ReadDir(procPath(pid, "fd"))
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// countSockets counts the open file descriptors of a process that refer to sockets
+func countSockets(pid int) (int, error) {
+	entries, err := os.ReadDir(procPath(pid, "fd"))
+	if err != nil {
+		return 0, err
+	}
+
+	sockets := 0
+	for _, entry := range entries {
+		// Socket descriptors link to "socket:[inode]"
+		target, err := os.Readlink(procPath(pid, "fd", entry.Name()))
+		if err == nil && strings.HasPrefix(target, "socket:") {
+			sockets++
+		}
+	}
+	return sockets, nil
+}
+
+// readProcLimits parses the resource limit table of /proc/[pid]/limits
+func readProcLimits(pid int) ([]models.ProcessLimit, error) {
+	data, err := os.ReadFile(procPath(pid, "limits"))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("empty limits for pid %d", pid)
+	}
+
+	// Columns are aligned, so take their offsets from the header line
+	header := lines[0]
+	softCol := strings.Index(header, "Soft Limit")
+	hardCol := strings.Index(header, "Hard Limit")
+	unitsCol := strings.Index(header, "Units")
+	if softCol < 0 || hardCol < 0 || unitsCol < 0 {
+		return nil, fmt.Errorf("unexpected limits format for pid %d", pid)
+	}
+
+	column := func(line string, start, end int) string {
+		if start >= len(line) {
+			return ""
+		}
+		if end > len(line) || end < 0 {
+			end = len(line)
+		}
+		return strings.TrimSpace(line[start:end])
+	}
+
+	limits := []models.ProcessLimit{}
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		limits = append(limits, models.ProcessLimit{
+			Name:  column(line, 0, softCol),
+			Soft:  column(line, softCol, hardCol),
+			Hard:  column(line, hardCol, unitsCol),
+			Units: column(line, unitsCol, -1),
+		})
+	}
+
+	return limits, nil
+}
+
+// readProcNamespaces returns the namespace identifiers a process belongs to
+func readProcNamespaces(pid int) (map[string]string, error) {
+	entries, err := os.ReadDir(procPath(pid, "ns"))
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make(map[string]string)
+	for _, entry := range entries {
+		// Links look like "net:[4026531833]"
+		target, err := os.Readlink(procPath(pid, "ns", entry.Name()))
+		if err != nil {
+			continue
+		}
+		namespaces[entry.Name()] = target
+	}
+
+	return namespaces, nil
+}
+
+// readProcEnviron returns the NUL separated KEY=value entries of /proc/[pid]/environ
+func readProcEnviron(pid int) ([]string, error) {
+	data, err := os.ReadFile(procPath(pid, "environ"))
+	if err != nil {
+		return nil, err
+	}
+
+	content := strings.TrimRight(string(data), "\x00")
+	if content == "" {
+		return []string{}, nil
+	}
+	return strings.Split(content, "\x00"), nil
+}
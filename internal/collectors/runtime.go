@@ -0,0 +1,271 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// Container runtimes accepted by SetContainerRuntime
+const (
+	RuntimeAuto       = "auto"
+	RuntimeDocker     = "docker"
+	RuntimePodman     = "podman"
+	RuntimeContainerd = "containerd"
+	RuntimeCgroup     = "cgroup"
+)
+
+// How long a candidate engine socket gets to answer during detection
+const runtimeProbeTimeout = time.Second
+
+// CRI sockets of containerd, k3s and CRI-O, tried in order
+var criSockets = []string{
+	"/run/containerd/containerd.sock",
+	"/run/k3s/containerd/containerd.sock",
+	"/var/run/crio/crio.sock",
+}
+
+// ContainerRuntime is a container engine that containers and their logs are read from
+type ContainerRuntime interface {
+	// Name identifies the engine, e.g. "docker" or "podman"
+	Name() string
+
+	// Containers lists containers with their details, along with the number of
+	// containers per Compose service keyed by composeServiceKey
+	Containers(ctx context.Context, display models.DockerDisplay) ([]models.ContainerInfo, map[string]int, error)
+
+	// FillUsage adds CPU and memory usage to running containers without cgroup stats
+	FillUsage(ctx context.Context, containers []models.ContainerInfo)
+
+	// FindContainer looks up a container by name or ID
+	FindContainer(ctx context.Context, nameOrID string) (models.ContainerInfo, error)
+
+	// FindContainersByLabel lists containers, stopped ones included, that have a
+	// KEY or KEY=VALUE label
+	FindContainersByLabel(ctx context.Context, label string) ([]models.ContainerInfo, error)
+
+	// ReadLogs streams a container's log lines and calls fn with the stream name
+	// ("stdout" or "stderr") and the line
+	ReadLogs(ctx context.Context, id string, options docker.LogOptions, fn func(stream, line string) error) error
+}
+
+// Container runtime selection, detected once on first use
+var (
+	runtimeChoice = RuntimeAuto
+	activeRuntime ContainerRuntime
+	runtimeErr    error
+	runtimeOnce   sync.Once
+)
+
+// SetContainerRuntime selects the engine containers are read from instead of detecting
+// it. Must be called before any container information is collected.
+func SetContainerRuntime(name string) error {
+	switch name {
+	case RuntimeAuto, RuntimeDocker, RuntimePodman, RuntimeContainerd, RuntimeCgroup:
+		runtimeChoice = name
+		return nil
+	}
+	return fmt.Errorf("unknown container runtime '%s', expected auto, docker, podman, containerd or cgroup", name)
+}
+
+// getContainerRuntime returns the selected container runtime, detecting it on first use
+func getContainerRuntime() (ContainerRuntime, error) {
+	runtimeOnce.Do(func() {
+		activeRuntime, runtimeErr = detectContainerRuntime(runtimeChoice)
+	})
+	return activeRuntime, runtimeErr
+}
+
+// containerRuntimeName returns the name of the runtime in use, or "" before one is detected
+func containerRuntimeName() string {
+	runtime, err := getContainerRuntime()
+	if err != nil || runtime == nil {
+		return ""
+	}
+	return runtime.Name()
+}
+
+// collectRuntimeContainers lists containers of the runtime in use. With usage set, running
+// containers get their resource usage from their cgroup when they run on this host, and
+// from the runtime otherwise.
+func collectRuntimeContainers(display models.DockerDisplay, usage bool) ([]models.ContainerInfo, map[string]int, error) {
+	runtime, err := getContainerRuntime()
+	if err != nil {
+		return []models.ContainerInfo{}, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dockerRequestTimeout)
+	defer cancel()
+
+	containers, desired, err := runtime.Containers(ctx, display)
+	if err != nil {
+		return containers, desired, err
+	}
+
+	for i := range containers {
+		if containers[i].Runtime == "" {
+			containers[i].Runtime = runtime.Name()
+		}
+	}
+	if !usage {
+		return containers, desired, nil
+	}
+
+	if runtime.Name() != RuntimeCgroup {
+		attachCgroupStats(containers)
+	}
+	runtime.FillUsage(ctx, containers)
+	return containers, desired, nil
+}
+
+// engineAPIClient returns the Engine API client of the runtime in use, for features that
+// only Docker and Podman provide, such as disk usage and events
+func engineAPIClient() (*docker.Client, error) {
+	runtime, err := getContainerRuntime()
+	if err != nil {
+		return nil, err
+	}
+
+	engine, ok := runtime.(*engineRuntime)
+	if !ok {
+		return nil, fmt.Errorf("%s does not provide the Docker Engine API, this needs Docker or Podman", runtime.Name())
+	}
+	return engine.client, nil
+}
+
+// detectContainerRuntime picks the engine to use. An explicit DOCKER_HOST, docker context
+// or CONTAINER_HOST wins, then the first Docker or Podman socket that answers, then a CRI socket when crictl
+// is installed, then container cgroups. Without any, Docker at the default socket is used
+// so errors say Docker isn't running.
+func detectContainerRuntime(choice string) (ContainerRuntime, error) {
+	switch choice {
+	case RuntimeDocker:
+		return newDockerEnvRuntime()
+	case RuntimePodman:
+		hosts := podmanHosts()
+		if len(hosts) == 0 {
+			return nil, fmt.Errorf("no Podman socket found, start it with `systemctl --user start podman.socket`")
+		}
+		return newEngineRuntime(RuntimePodman, hosts[0])
+	case RuntimeContainerd:
+		endpoint := criEndpoint()
+		if endpoint == "" {
+			return nil, fmt.Errorf("no containerd or CRI-O socket found")
+		}
+		return newCRIRuntime(endpoint)
+	case RuntimeCgroup:
+		if _, err := cgroupV2Root(); err != nil {
+			return nil, err
+		}
+		return &cgroupRuntime{}, nil
+	}
+
+	if os.Getenv("DOCKER_HOST") != "" || docker.ContextSelected() {
+		return newDockerEnvRuntime()
+	}
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		return newEngineRuntime(RuntimePodman, host)
+	}
+
+	candidates := []string{docker.DefaultHost}
+	candidates = append(candidates, podmanHosts()...)
+	for _, host := range candidates {
+		if !socketExists(host) {
+			continue
+		}
+		runtime, err := newEngineRuntime(engineNameForHost(host), host)
+		if err != nil {
+			continue
+		}
+		if runtime.ping() == nil {
+			return runtime, nil
+		}
+	}
+
+	if endpoint := criEndpoint(); endpoint != "" {
+		if _, err := exec.LookPath("crictl"); err == nil {
+			return newCRIRuntime(endpoint)
+		}
+	}
+
+	// Usage without names still beats nothing, e.g. on a node whose engine isn't reachable
+	if cgroups, err := findContainerCgroups(); err == nil && len(cgroups) > 0 {
+		return &cgroupRuntime{}, nil
+	}
+
+	return newEngineRuntime(RuntimeDocker, docker.DefaultHost)
+}
+
+// newDockerEnvRuntime connects to DOCKER_HOST, the docker context in use, or the default socket
+func newDockerEnvRuntime() (*engineRuntime, error) {
+	client, err := docker.NewClientFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return &engineRuntime{name: engineNameForHost(client.Host), client: client}, nil
+}
+
+// podmanHosts returns the Podman API sockets that exist: CONTAINER_HOST, the rootless
+// socket of the current user, then the rootful one
+func podmanHosts() []string {
+	if host := os.Getenv("CONTAINER_HOST"); host != "" {
+		return []string{host}
+	}
+
+	paths := []string{}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		paths = append(paths, filepath.Join(runtimeDir, "podman", "podman.sock"))
+	}
+	paths = append(paths, fmt.Sprintf("/run/user/%d/podman/podman.sock", os.Getuid()), "/run/podman/podman.sock")
+
+	hosts := []string{}
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		host := "unix://" + path
+		if !seen[host] && socketExists(host) {
+			seen[host] = true
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// criEndpoint returns CONTAINER_RUNTIME_ENDPOINT, or the first CRI socket that exists
+func criEndpoint() string {
+	if endpoint := os.Getenv("CONTAINER_RUNTIME_ENDPOINT"); endpoint != "" {
+		return endpoint
+	}
+	for _, path := range criSockets {
+		if socketExists("unix://" + path) {
+			return "unix://" + path
+		}
+	}
+	return ""
+}
+
+// engineNameForHost tells Podman sockets apart from Docker ones by their path
+func engineNameForHost(host string) string {
+	if strings.Contains(host, "podman") {
+		return RuntimePodman
+	}
+	return RuntimeDocker
+}
+
+// socketExists reports whether a unix:// host's socket file exists. Other hosts are
+// assumed to exist, they can only be checked by connecting.
+func socketExists(host string) bool {
+	path := strings.TrimPrefix(host, "unix://")
+	if path == host {
+		return true
+	}
+	info, err := os.Stat(path)
+	return err == nil && info.Mode()&os.ModeSocket != 0
+}
@@ -0,0 +1,90 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// cgroupRuntime reads container usage straight from the cgroup v2 filesystem, for hosts
+// where no engine can be reached. Names, images, labels and logs are only known to the
+// engine, so containers are identified by ID and pod UID.
+type cgroupRuntime struct{}
+
+// Name returns "cgroup"
+func (r *cgroupRuntime) Name() string {
+	return RuntimeCgroup
+}
+
+// Containers lists the containers that have a cgroup, which are the running ones.
+// Compose projects are labels, so none match a project.
+func (r *cgroupRuntime) Containers(ctx context.Context, display models.DockerDisplay) ([]models.ContainerInfo, map[string]int, error) {
+	if display.Project != "" {
+		return []models.ContainerInfo{}, map[string]int{}, nil
+	}
+
+	cgroups, err := findContainerCgroups()
+	if err != nil {
+		return []models.ContainerInfo{}, nil, err
+	}
+
+	stats := sampleContainerCgroups(cgroups)
+	root, _ := cgroupV2Root()
+
+	containers := make([]models.ContainerInfo, 0, len(cgroups))
+	for _, cgroup := range cgroups {
+		container := containerFromCgroup(cgroup)
+		if cgroupStats, ok := stats[container.ID]; ok {
+			applyCgroupStats(&container, root, cgroupStats)
+		}
+		containers = append(containers, container)
+	}
+	return containers, map[string]int{}, nil
+}
+
+// FillUsage does nothing, usage comes with the listing
+func (r *cgroupRuntime) FillUsage(ctx context.Context, containers []models.ContainerInfo) {}
+
+// FindContainer matches a container ID or ID prefix
+func (r *cgroupRuntime) FindContainer(ctx context.Context, nameOrID string) (models.ContainerInfo, error) {
+	cgroups, err := findContainerCgroups()
+	if err != nil {
+		return models.ContainerInfo{}, err
+	}
+
+	for _, cgroup := range cgroups {
+		if strings.HasPrefix(cgroup.ID, nameOrID) {
+			return containerFromCgroup(cgroup), nil
+		}
+	}
+	return models.ContainerInfo{}, fmt.Errorf("container '%s' not found, only IDs are known without a container engine", nameOrID)
+}
+
+// FindContainersByLabel fails, labels are only known to the engine
+func (r *cgroupRuntime) FindContainersByLabel(ctx context.Context, label string) ([]models.ContainerInfo, error) {
+	return nil, fmt.Errorf("selecting containers by label needs Docker, Podman or containerd")
+}
+
+// ReadLogs fails, logs are only known to the engine
+func (r *cgroupRuntime) ReadLogs(ctx context.Context, id string, options docker.LogOptions, fn func(stream, line string) error) error {
+	return fmt.Errorf("container logs need Docker, Podman or containerd")
+}
+
+// containerFromCgroup describes a container known only by its cgroup
+func containerFromCgroup(cgroup containerCgroup) models.ContainerInfo {
+	container := models.ContainerInfo{
+		ID:      shortContainerID(cgroup.ID),
+		Name:    shortContainerID(cgroup.ID),
+		State:   "running",
+		Status:  "Running",
+		Runtime: cgroup.Runtime,
+		PodUID:  cgroup.PodUID,
+	}
+	if container.Runtime == "" {
+		container.Runtime = RuntimeCgroup
+	}
+	return container
+}
@@ -0,0 +1,543 @@
+package collectors
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// Containers inspected per crictl call
+const criInspectBatchSize = 50
+
+// Labels the kubelet puts on containers with the name and UID of their pod
+const (
+	kubernetesPodLabel    = "io.kubernetes.pod.name"
+	kubernetesPodUIDLabel = "io.kubernetes.pod.uid"
+)
+
+// criRuntime reads containers from containerd or CRI-O over the Container Runtime
+// Interface. CRI is a gRPC API, so it is spoken through crictl rather than directly.
+type criRuntime struct {
+	name     string
+	endpoint string
+}
+
+// criInt is a 64-bit integer, which crictl writes as a JSON string
+type criInt int64
+
+func (i *criInt) UnmarshalJSON(data []byte) error {
+	value := strings.Trim(string(data), `"`)
+	if value == "" || value == "null" {
+		*i = 0
+		return nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = criInt(parsed)
+	return nil
+}
+
+// criMetadata names a container within its pod
+type criMetadata struct {
+	Name    string `json:"name"`
+	Attempt int    `json:"attempt"`
+}
+
+// criImageSpec names an image
+type criImageSpec struct {
+	Image string `json:"image"`
+}
+
+// criContainer is an entry of `crictl ps -o json`
+type criContainer struct {
+	ID        string            `json:"id"`
+	Metadata  criMetadata       `json:"metadata"`
+	Image     criImageSpec      `json:"image"`
+	ImageRef  string            `json:"imageRef"`
+	State     string            `json:"state"`
+	CreatedAt criInt            `json:"createdAt"` // Nanoseconds since the epoch
+	Labels    map[string]string `json:"labels"`
+}
+
+// criContainerStatus is the status part of `crictl inspect -o json`
+type criContainerStatus struct {
+	Status struct {
+		ID         string       `json:"id"`
+		State      string       `json:"state"`
+		StartedAt  string       `json:"startedAt"`
+		FinishedAt string       `json:"finishedAt"`
+		ExitCode   int          `json:"exitCode"`
+		Reason     string       `json:"reason"`
+		Image      criImageSpec `json:"image"`
+	} `json:"status"`
+}
+
+// criValue is a CRI stats value, unset when the runtime doesn't report it
+type criValue struct {
+	Value criInt `json:"value"`
+}
+
+// criContainerStats is an entry of `crictl stats -o json`
+type criContainerStats struct {
+	Attributes struct {
+		ID string `json:"id"`
+	} `json:"attributes"`
+	CPU struct {
+		UsageNanoCores *criValue `json:"usageNanoCores"`
+	} `json:"cpu"`
+	Memory struct {
+		WorkingSetBytes *criValue `json:"workingSetBytes"`
+		AvailableBytes  *criValue `json:"availableBytes"`
+	} `json:"memory"`
+}
+
+// newCRIRuntime creates a CRI runtime for an endpoint such as unix:///run/containerd/containerd.sock
+func newCRIRuntime(endpoint string) (*criRuntime, error) {
+	if _, err := exec.LookPath("crictl"); err != nil {
+		return nil, fmt.Errorf("crictl is needed to read containers from %s: %w", endpoint, err)
+	}
+
+	name := RuntimeContainerd
+	if strings.Contains(endpoint, "crio") {
+		name = "cri-o"
+	}
+	return &criRuntime{name: name, endpoint: endpoint}, nil
+}
+
+// Name returns "containerd" or "cri-o"
+func (r *criRuntime) Name() string {
+	return r.name
+}
+
+// Containers lists containers with their status and, for running ones, CPU and memory
+// usage. Compose labels are honoured, as set by nerdctl compose.
+func (r *criRuntime) Containers(ctx context.Context, display models.DockerDisplay) ([]models.ContainerInfo, map[string]int, error) {
+	all, err := r.list(ctx)
+	if err != nil {
+		return []models.ContainerInfo{}, nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	desired := make(map[string]int)
+	result := []models.ContainerInfo{}
+	for _, entry := range all {
+		container := containerFromCRI(entry)
+		if display.Project != "" && container.Project != display.Project {
+			continue
+		}
+		if container.Service != "" {
+			desired[composeServiceKey(container.Project, container.Service)]++
+		}
+		if display.All || isActiveContainerState(container.State) {
+			result = append(result, container)
+		}
+	}
+
+	// crictl inspect takes several containers, so nodes with hundreds of exited
+	// containers don't start a process for each
+	semaphore := make(chan struct{}, dockerFetchConcurrency)
+	var wg sync.WaitGroup
+	for start := 0; start < len(result); start += criInspectBatchSize {
+		end := start + criInspectBatchSize
+		if end > len(result) {
+			end = len(result)
+		}
+
+		wg.Add(1)
+		go func(batch []models.ContainerInfo) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			r.fillStatuses(ctx, batch)
+		}(result[start:end])
+	}
+	wg.Wait()
+
+	return result, desired, nil
+}
+
+// FillUsage reads CPU and memory usage from the runtime for running containers without
+// cgroup stats. One stats call covers all of them.
+func (r *criRuntime) FillUsage(ctx context.Context, containers []models.ContainerInfo) {
+	missing := false
+	for _, container := range containers {
+		if container.State == "running" && container.Cgroup == nil {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return
+	}
+
+	stats, err := r.stats(ctx)
+	if err != nil {
+		return
+	}
+	for i := range containers {
+		if containers[i].State != "running" || containers[i].Cgroup != nil {
+			continue
+		}
+		if sample, ok := stats[containers[i].ID]; ok {
+			applyCRIStats(&containers[i], sample)
+		}
+	}
+}
+
+// FindContainer matches an ID prefix, a container name or a pod/container name. Kubernetes
+// keeps exited containers of earlier attempts around, so the newest match is used.
+func (r *criRuntime) FindContainer(ctx context.Context, nameOrID string) (models.ContainerInfo, error) {
+	all, err := r.list(ctx)
+	if err != nil {
+		return models.ContainerInfo{}, err
+	}
+
+	var found *criContainer
+	for i, entry := range all {
+		container := containerFromCRI(entry)
+		if !strings.HasPrefix(entry.ID, nameOrID) && entry.Metadata.Name != nameOrID && container.Name != nameOrID {
+			continue
+		}
+		if found == nil || entry.CreatedAt > found.CreatedAt {
+			found = &all[i]
+		}
+	}
+
+	if found == nil {
+		return models.ContainerInfo{}, fmt.Errorf("container '%s' not found", nameOrID)
+	}
+	return containerFromCRI(*found), nil
+}
+
+// FindContainersByLabel lists containers with a KEY or KEY=VALUE label
+func (r *criRuntime) FindContainersByLabel(ctx context.Context, label string) ([]models.ContainerInfo, error) {
+	all, err := r.list(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, value, hasValue := strings.Cut(label, "=")
+	containers := []models.ContainerInfo{}
+	for _, entry := range all {
+		actual, ok := entry.Labels[key]
+		if ok && (!hasValue || actual == value) {
+			containers = append(containers, containerFromCRI(entry))
+		}
+	}
+	return containers, nil
+}
+
+// ReadLogs runs `crictl logs`, which writes the container's stdout and stderr to its own.
+// crictl has no --until, so later lines are dropped here and following stops at that time.
+func (r *criRuntime) ReadLogs(ctx context.Context, id string, options docker.LogOptions, fn func(stream, line string) error) error {
+	args := []string{"logs"}
+	if options.Timestamps || !options.Until.IsZero() {
+		args = append(args, "--timestamps")
+	}
+	if options.Follow {
+		args = append(args, "--follow")
+	}
+	if options.Tail != "" && options.Tail != "all" {
+		args = append(args, "--tail", options.Tail)
+	}
+	if !options.Since.IsZero() {
+		args = append(args, "--since", options.Since.UTC().Format(time.RFC3339))
+	}
+	args = append(args, id)
+
+	if options.Follow && !options.Until.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, options.Until)
+		defer cancel()
+	}
+
+	// Stops crictl once fn fails, a followed stream would otherwise never end
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	cmd := r.command(ctx, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	var fnErr error
+	read := func(stream string, pipe io.Reader) {
+		scanner := bufio.NewScanner(pipe)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if !options.Until.IsZero() && parseLogLine(line).Timestamp.After(options.Until) {
+				continue
+			}
+			if !options.Timestamps {
+				line = parseLogLine(line).Message
+			}
+
+			mu.Lock()
+			if fnErr == nil {
+				if fnErr = fn(stream, line); fnErr != nil {
+					stop()
+				}
+			}
+			mu.Unlock()
+		}
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		read("stdout", stdout)
+	}()
+	go func() {
+		defer wg.Done()
+		read("stderr", stderr)
+	}()
+	wg.Wait()
+
+	err = cmd.Wait()
+	if fnErr != nil {
+		return fnErr
+	}
+	// crictl's own errors end up in the stderr stream; a deadline at -until is a normal end
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("crictl logs: %w", err)
+	}
+	return nil
+}
+
+// list returns every container, running or not
+func (r *criRuntime) list(ctx context.Context) ([]criContainer, error) {
+	var out struct {
+		Containers []criContainer `json:"containers"`
+	}
+	if err := r.runJSON(ctx, &out, "ps", "--all", "--output", "json"); err != nil {
+		return nil, err
+	}
+	return out.Containers, nil
+}
+
+// fillStatuses adds start and finish times, the exit code and the image name from
+// `crictl inspect`. A container removed meanwhile fails the whole call, so the batch is
+// then inspected one by one; failures leave the fields empty.
+func (r *criRuntime) fillStatuses(ctx context.Context, containers []models.ContainerInfo) {
+	ids := make([]string, len(containers))
+	for i, container := range containers {
+		ids[i] = container.ID
+	}
+
+	statuses, err := r.inspect(ctx, ids)
+	if err != nil && len(containers) > 1 {
+		for i := range containers {
+			r.fillStatuses(ctx, containers[i:i+1])
+		}
+		return
+	}
+
+	for i := range containers {
+		if status, ok := statuses[containers[i].ID]; ok {
+			applyCRIStatus(&containers[i], status)
+		}
+	}
+}
+
+// inspect runs `crictl inspect` for containers and returns their status keyed by short
+// ID. crictl writes one JSON document per container, or a list in newer versions.
+func (r *criRuntime) inspect(ctx context.Context, ids []string) (map[string]criContainerStatus, error) {
+	args := append([]string{"inspect", "--output", "json"}, ids...)
+	output, err := r.command(ctx, args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("crictl inspect: %w", err)
+	}
+
+	var inspected []criContainerStatus
+	decoder := json.NewDecoder(bytes.NewReader(output))
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to parse crictl inspect output: %w", err)
+		}
+
+		var list []criContainerStatus
+		if json.Unmarshal(raw, &list) == nil {
+			inspected = append(inspected, list...)
+			continue
+		}
+		var status criContainerStatus
+		if err := json.Unmarshal(raw, &status); err != nil {
+			return nil, fmt.Errorf("failed to parse crictl inspect output: %w", err)
+		}
+		inspected = append(inspected, status)
+	}
+
+	statuses := make(map[string]criContainerStatus, len(inspected))
+	for _, status := range inspected {
+		statuses[shortContainerID(status.Status.ID)] = status
+	}
+	return statuses, nil
+}
+
+// applyCRIStatus sets the fields only `crictl inspect` reports
+func applyCRIStatus(container *models.ContainerInfo, inspect criContainerStatus) {
+	status := inspect.Status
+	container.StartedAt = parseCRITime(status.StartedAt)
+	container.FinishedAt = parseCRITime(status.FinishedAt)
+	container.ExitCode = status.ExitCode
+	container.OOMKilled = status.Reason == "OOMKilled"
+	if image := status.Image.Image; image != "" && !strings.HasPrefix(image, "sha256:") {
+		container.Image = image
+	}
+
+	switch container.State {
+	case "running":
+		if !container.StartedAt.IsZero() {
+			container.Status = "Up " + strings.TrimSuffix(formatSince(container.StartedAt), " ago")
+		}
+	case "exited":
+		container.Status = fmt.Sprintf("Exited (%d)", container.ExitCode)
+		if !container.FinishedAt.IsZero() {
+			container.Status += " " + formatSince(container.FinishedAt)
+		}
+	}
+}
+
+// stats returns CPU and memory usage of running containers keyed by short ID
+func (r *criRuntime) stats(ctx context.Context) (map[string]criContainerStats, error) {
+	var out struct {
+		Stats []criContainerStats `json:"stats"`
+	}
+	if err := r.runJSON(ctx, &out, "stats", "--output", "json"); err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]criContainerStats, len(out.Stats))
+	for _, sample := range out.Stats {
+		stats[shortContainerID(sample.Attributes.ID)] = sample
+	}
+	return stats, nil
+}
+
+// runJSON runs a crictl command and decodes its JSON output
+func (r *criRuntime) runJSON(ctx context.Context, out interface{}, args ...string) error {
+	output, err := r.command(ctx, args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return fmt.Errorf("crictl %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return fmt.Errorf("crictl %s: %w", args[0], err)
+	}
+
+	if err := json.Unmarshal(output, out); err != nil {
+		return fmt.Errorf("failed to parse crictl %s output: %w", args[0], err)
+	}
+	return nil
+}
+
+// command builds a crictl command against the runtime's endpoint
+func (r *criRuntime) command(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "crictl", append([]string{"--runtime-endpoint", r.endpoint}, args...)...)
+}
+
+// containerFromCRI converts a container list entry. Kubernetes containers are named
+// after their pod, as container names only are unique within a pod.
+func containerFromCRI(entry criContainer) models.ContainerInfo {
+	container := models.ContainerInfo{
+		ID:           shortContainerID(entry.ID),
+		Name:         entry.Metadata.Name,
+		Image:        entry.Image.Image,
+		State:        criState(entry.State),
+		RestartCount: entry.Metadata.Attempt,
+		Project:      entry.Labels[composeProjectLabel],
+		PodUID:       entry.Labels[kubernetesPodUIDLabel],
+	}
+
+	if pod := entry.Labels[kubernetesPodLabel]; pod != "" {
+		container.Name = pod + "/" + entry.Metadata.Name
+	}
+	if strings.HasPrefix(container.Image, "sha256:") && entry.ImageRef != "" {
+		container.Image = entry.ImageRef
+	}
+	if strings.HasPrefix(container.Image, "sha256:") {
+		container.Image = shortImageID(container.Image)
+	}
+	if entry.CreatedAt > 0 {
+		container.CreatedAt = time.Unix(0, int64(entry.CreatedAt))
+	}
+	if !strings.EqualFold(entry.Labels[composeOneOffLabel], "true") {
+		container.Service = entry.Labels[composeServiceLabel]
+	}
+
+	container.Status = strings.ToUpper(container.State[:1]) + container.State[1:]
+	return container
+}
+
+// applyCRIStats sets usage from a stats sample. CPU usage is in nanocores, so 1e9 is one
+// full core; the limit is the working set plus what is still available.
+func applyCRIStats(container *models.ContainerInfo, sample criContainerStats) {
+	if sample.CPU.UsageNanoCores != nil {
+		container.CPUPercent = float64(sample.CPU.UsageNanoCores.Value) / 1e7
+	}
+	if sample.Memory.WorkingSetBytes == nil {
+		return
+	}
+
+	container.MemoryUsage = uint64(sample.Memory.WorkingSetBytes.Value)
+	if sample.Memory.AvailableBytes != nil && sample.Memory.AvailableBytes.Value > 0 {
+		container.MemoryLimit = container.MemoryUsage + uint64(sample.Memory.AvailableBytes.Value)
+		container.MemoryPerc = float64(container.MemoryUsage) / float64(container.MemoryLimit) * 100
+	}
+}
+
+// isCRIRuntime reports whether containers come from a CRI runtime, which starts a new
+// container for every restart of a Kubernetes container
+func isCRIRuntime(name string) bool {
+	return name == RuntimeContainerd || name == "cri-o"
+}
+
+// criState maps CRI container states such as CONTAINER_RUNNING to Docker's names
+func criState(state string) string {
+	switch state {
+	case "CONTAINER_CREATED":
+		return "created"
+	case "CONTAINER_RUNNING":
+		return "running"
+	case "CONTAINER_EXITED":
+		return "exited"
+	}
+	return "unknown"
+}
+
+// parseCRITime parses a crictl timestamp. Unset times come back as the Unix epoch.
+func parseCRITime(value string) time.Time {
+	parsed := parseDockerTime(value)
+	if parsed.Unix() <= 0 {
+		return time.Time{}
+	}
+	return parsed
+}
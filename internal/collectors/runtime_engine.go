@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tiwariParth/whosay/internal/docker"
+	"github.com/tiwariParth/whosay/internal/models"
+)
+
+// engineRuntime reads containers through the Docker Engine API, which Podman serves on
+// its Docker-compatible socket as well
+type engineRuntime struct {
+	name   string
+	client *docker.Client
+}
+
+// newEngineRuntime creates an Engine API runtime for a host such as unix:///var/run/docker.sock
+func newEngineRuntime(name, host string) (*engineRuntime, error) {
+	client, err := docker.NewClient(host)
+	if err != nil {
+		return nil, err
+	}
+	return &engineRuntime{name: name, client: client}, nil
+}
+
+// Name returns "docker" or "podman"
+func (r *engineRuntime) Name() string {
+	return r.name
+}
+
+// ping checks that the engine answers
+func (r *engineRuntime) ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), runtimeProbeTimeout)
+	defer cancel()
+	return r.client.Ping(ctx)
+}
+
+// Containers lists containers with inspect data
+func (r *engineRuntime) Containers(ctx context.Context, display models.DockerDisplay) ([]models.ContainerInfo, map[string]int, error) {
+	return collectContainers(ctx, r.client, display)
+}
+
+// FillUsage fetches daemon stats, for containers of a remote engine or hosts without cgroup v2
+func (r *engineRuntime) FillUsage(ctx context.Context, containers []models.ContainerInfo) {
+	fillContainerStats(ctx, r.client, containers)
+}
+
+// FindContainer inspects a container by name or ID
+func (r *engineRuntime) FindContainer(ctx context.Context, nameOrID string) (models.ContainerInfo, error) {
+	inspect, err := r.client.InspectContainer(ctx, nameOrID)
+	if err != nil {
+		if docker.IsNotFound(err) {
+			return models.ContainerInfo{}, fmt.Errorf("container '%s' not found", nameOrID)
+		}
+		return models.ContainerInfo{}, err
+	}
+
+	return models.ContainerInfo{
+		ID:    shortContainerID(inspect.ID),
+		Name:  strings.TrimPrefix(inspect.Name, "/"),
+		Image: inspect.Config.Image,
+		State: inspect.State.Status,
+	}, nil
+}
+
+// FindContainersByLabel lists containers with a label filter
+func (r *engineRuntime) FindContainersByLabel(ctx context.Context, label string) ([]models.ContainerInfo, error) {
+	list, err := r.client.ListContainers(ctx, docker.ListOptions{
+		All:     true,
+		Filters: map[string][]string{"label": {label}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := make([]models.ContainerInfo, len(list))
+	for i, summary := range list {
+		containers[i] = containerFromSummary(summary)
+	}
+	return containers, nil
+}
+
+// ReadLogs streams a container's logs. Containers with a TTY have a single raw stream,
+// others multiplex stdout and stderr.
+func (r *engineRuntime) ReadLogs(ctx context.Context, id string, options docker.LogOptions, fn func(stream, line string) error) error {
+	inspect, err := r.client.InspectContainer(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	stream, err := r.client.ContainerLogs(ctx, id, options)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	return docker.ReadLogLines(stream, inspect.Config.Tty, fn)
+}
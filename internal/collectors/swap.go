@@ -0,0 +1,287 @@
+package collectors
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tiwariParth/whosay/internal/models"
+	"github.com/tiwariParth/whosay/internal/ui"
+)
+
+// Interval used for the second reading when no previous swap activity sample exists
+const swapSampleInterval = 250 * time.Millisecond
+
+// Swap activity counters from /proc/vmstat, kept across watch mode ticks
+var (
+	lastSwapIn     uint64
+	lastSwapOut    uint64
+	lastSwapReadAt time.Time
+	hasSwapSample  bool
+	swapActivityMu sync.Mutex
+)
+
+// getSwapSection formats swap information for the memory display
+func getSwapSection(swap models.SwapInfo, opts models.Options) [][]string {
+	if swap.Total == 0 && len(swap.Zram) == 0 {
+		return [][]string{
+			{"Status", "No swap configured"},
+		}
+	}
+	
+	swapData := [][]string{
+		{"Total", formatBytes(swap.Total)},
+		{"Used", formatBytes(swap.Used)},
+		{"Free", formatBytes(swap.Free)},
+		{"Activity", fmt.Sprintf("in %s/s / out %s/s",
+			formatBytes(uint64(swap.SwapInRate)), formatBytes(uint64(swap.SwapOutRate)))},
+	}
+	
+	// List the backing devices and files
+	for _, device := range swap.Devices {
+		name := device.Path
+		if opts.VerboseOutput {
+			name = fmt.Sprintf("%s (%s, prio %d)", device.Path, device.Type, device.Priority)
+		}
+		swapData = append(swapData, []string{
+			"Device", fmt.Sprintf("%s %s / %s", name, formatBytes(device.Used), formatBytes(device.Size)),
+		})
+	}
+	
+	// Compressed swap in RAM
+	for _, zram := range swap.Zram {
+		swapData = append(swapData, []string{
+			zram.Name, fmt.Sprintf("%s → %s (%.1fx, %s in RAM)",
+				formatBytes(zram.OrigDataSize), formatBytes(zram.ComprDataSize),
+				zram.CompressionRatio, formatBytes(zram.MemUsedTotal)),
+		})
+	}
+	
+	if swap.ZswapStored > 0 {
+		swapData = append(swapData, []string{
+			"Zswap", fmt.Sprintf("%s stored in %s pool", formatBytes(swap.ZswapStored), formatBytes(swap.ZswapPool)),
+		})
+	}
+	
+	barWidth := 20
+	if opts.CompactMode {
+		barWidth = 15
+	}
+	
+	swapData = append(swapData, []string{
+		"Usage", ui.PrintCompactUsageBar("", swap.UsagePerc, barWidth),
+	})
+	
+	return swapData
+}
+
+// collectSwapInfo gathers swap information, using already parsed /proc/meminfo fields on Linux
+func collectSwapInfo(meminfo map[string]uint64) models.SwapInfo {
+	var swap models.SwapInfo
+
+	switch runtime.GOOS {
+	case "linux":
+		swap.Total = meminfo["SwapTotal"]
+		swap.Free = meminfo["SwapFree"]
+		swap.ZswapPool = meminfo["Zswap"]
+		swap.ZswapStored = meminfo["Zswapped"]
+		swap.Devices = readSwapDevices()
+		swap.Zram = readZramDevices()
+		swap.SwapInRate, swap.SwapOutRate = sampleSwapActivity()
+	case "darwin":
+		swap = getDarwinSwapInfo()
+	}
+
+	if swap.Free > swap.Total {
+		swap.Free = swap.Total
+	}
+	swap.Used = swap.Total - swap.Free
+
+	if swap.Total > 0 {
+		swap.UsagePerc = float64(swap.Used) / float64(swap.Total) * 100
+	}
+
+	return swap
+}
+
+// readSwapDevices parses the active swap areas from /proc/swaps
+func readSwapDevices() []models.SwapDevice {
+	file, err := os.Open("/proc/swaps")
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	devices := []models.SwapDevice{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // Skip the header line
+	for scanner.Scan() {
+		// Filename Type Size Used Priority, with sizes in KB
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 {
+			continue
+		}
+
+		size, _ := strconv.ParseUint(fields[2], 10, 64)
+		used, _ := strconv.ParseUint(fields[3], 10, 64)
+		priority, _ := strconv.Atoi(fields[4])
+
+		devices = append(devices, models.SwapDevice{
+			Path:     strings.ReplaceAll(fields[0], "\\040", " "),
+			Type:     fields[1],
+			Size:     size * 1024,
+			Used:     used * 1024,
+			Priority: priority,
+		})
+	}
+
+	return devices
+}
+
+// readZramDevices reads compression statistics for zram block devices
+func readZramDevices() []models.ZramDevice {
+	paths, err := filepath.Glob("/sys/block/zram*/mm_stat")
+	if err != nil || len(paths) == 0 {
+		return nil
+	}
+
+	devices := []models.ZramDevice{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		// orig_data_size compr_data_size mem_used_total mem_limit ...
+		fields := strings.Fields(string(data))
+		if len(fields) < 3 {
+			continue
+		}
+
+		device := models.ZramDevice{
+			Name: filepath.Base(filepath.Dir(path)),
+		}
+		device.OrigDataSize, _ = strconv.ParseUint(fields[0], 10, 64)
+		device.ComprDataSize, _ = strconv.ParseUint(fields[1], 10, 64)
+		device.MemUsedTotal, _ = strconv.ParseUint(fields[2], 10, 64)
+
+		// Unused devices have nothing to compress
+		if device.OrigDataSize == 0 {
+			continue
+		}
+
+		if device.ComprDataSize > 0 {
+			device.CompressionRatio = float64(device.OrigDataSize) / float64(device.ComprDataSize)
+		}
+
+		devices = append(devices, device)
+	}
+
+	return devices
+}
+
+// sampleSwapActivity returns swap-in and swap-out rates in bytes per second
+// since the previous reading of /proc/vmstat
+func sampleSwapActivity() (float64, float64) {
+	swapActivityMu.Lock()
+	defer swapActivityMu.Unlock()
+
+	swapIn, swapOut, err := readVMStatSwap()
+	if err != nil {
+		return 0, 0
+	}
+
+	// Without a previous reading, take a second one after a short interval
+	if !hasSwapSample {
+		lastSwapIn, lastSwapOut = swapIn, swapOut
+		lastSwapReadAt = time.Now()
+		hasSwapSample = true
+		time.Sleep(swapSampleInterval)
+
+		swapIn, swapOut, err = readVMStatSwap()
+		if err != nil {
+			return 0, 0
+		}
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(lastSwapReadAt).Seconds()
+	if elapsed < 0.1 {
+		elapsed = 0.1 // Prevent unrealistically small time periods
+	}
+
+	pageSize := float64(os.Getpagesize())
+	inRate := float64(counterDelta(lastSwapIn, swapIn)) * pageSize / elapsed
+	outRate := float64(counterDelta(lastSwapOut, swapOut)) * pageSize / elapsed
+
+	lastSwapIn = swapIn
+	lastSwapOut = swapOut
+	lastSwapReadAt = now
+
+	return inRate, outRate
+}
+
+// readVMStatSwap reads the cumulative pswpin and pswpout page counters
+func readVMStatSwap() (uint64, uint64, error) {
+	file, err := os.Open("/proc/vmstat")
+	if err != nil {
+		return 0, 0, err
+	}
+	defer file.Close()
+
+	var swapIn, swapOut uint64
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case "pswpin":
+			swapIn, _ = strconv.ParseUint(fields[1], 10, 64)
+		case "pswpout":
+			swapOut, _ = strconv.ParseUint(fields[1], 10, 64)
+		}
+	}
+
+	return swapIn, swapOut, scanner.Err()
+}
+
+// getDarwinSwapInfo reads swap usage on macOS from sysctl
+func getDarwinSwapInfo() models.SwapInfo {
+	var swap models.SwapInfo
+
+	// Output looks like "total = 2048.00M  used = 1200.00M  free = 848.00M  (encrypted)"
+	output, err := exec.Command("sysctl", "-n", "vm.swapusage").Output()
+	if err != nil {
+		return swap
+	}
+
+	swapRegex := regexp.MustCompile(`(total|free) = ([\d.]+)M`)
+	for _, matches := range swapRegex.FindAllStringSubmatch(string(output), -1) {
+		value, err := strconv.ParseFloat(matches[2], 64)
+		if err != nil {
+			continue
+		}
+
+		bytes := uint64(value * 1024 * 1024)
+		if matches[1] == "total" {
+			swap.Total = bytes
+		} else {
+			swap.Free = bytes
+		}
+	}
+
+	return swap
+}
@@ -0,0 +1,271 @@
+package docker
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// DefaultHost is the Engine API socket used when DOCKER_HOST is not set
+const DefaultHost = "unix:///var/run/docker.sock"
+
+// ErrDaemonUnavailable is returned when the Engine API socket can't be reached
+var ErrDaemonUnavailable = errors.New("cannot connect to the Docker daemon")
+
+// APIError is an error response from the Engine API
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("docker API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether the error is a 404 from the Engine API
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// Client talks to the Docker Engine API over a unix socket or TCP
+type Client struct {
+	Host       string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClientFromEnv creates a client for DOCKER_HOST, the docker context in use, or the
+// default socket
+func NewClientFromEnv() (*Client, error) {
+	if host := os.Getenv("DOCKER_HOST"); host != "" {
+		return NewClient(host)
+	}
+
+	dockerCtx, err := currentContext()
+	if err != nil {
+		return nil, err
+	}
+	if dockerCtx != nil {
+		return newClient(dockerCtx.host, dockerCtx.tlsConfig)
+	}
+	return NewClient(DefaultHost)
+}
+
+// NewClient creates a client for a host such as unix:///var/run/docker.sock or tcp://127.0.0.1:2375.
+// TCP connections use TLS when DOCKER_TLS_VERIFY is set, with the certificates in DOCKER_CERT_PATH.
+func NewClient(host string) (*Client, error) {
+	tlsConfig, err := tlsConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS settings for docker host '%s': %w", host, err)
+	}
+	return newClient(host, tlsConfig)
+}
+
+// newClient creates a client, using TLS for TCP hosts when tlsConfig is set
+func newClient(host string, tlsConfig *tls.Config) (*Client, error) {
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid docker host '%s': %w", host, err)
+	}
+
+	transport := &http.Transport{
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     30 * time.Second,
+	}
+	client := &Client{Host: host}
+
+	switch u.Scheme {
+	case "unix":
+		socketPath := u.Path
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}
+		// The host part is ignored when dialing the socket
+		client.baseURL = "http://docker"
+	case "tcp", "http":
+		client.baseURL = "http://" + u.Host
+		if tlsConfig != nil {
+			transport.TLSClientConfig = tlsConfig
+			client.baseURL = "https://" + u.Host
+		}
+	case "https":
+		transport.TLSClientConfig = tlsConfig
+		client.baseURL = "https://" + u.Host
+	case "npipe", "ssh":
+		return nil, fmt.Errorf("%s:// docker hosts are not supported, expose the daemon on tcp:// (with DOCKER_TLS_VERIFY) instead", u.Scheme)
+	default:
+		return nil, fmt.Errorf("unsupported docker host scheme '%s'", u.Scheme)
+	}
+
+	client.httpClient = &http.Client{Transport: transport}
+	return client, nil
+}
+
+// Ping checks that the daemon is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	resp, err := c.do(ctx, http.MethodGet, "/_ping", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// ListOptions selects the containers returned by ListContainers
+type ListOptions struct {
+	All     bool                // Include stopped containers
+	Filters map[string][]string // Engine API filters, e.g. {"label": {"com.docker.compose.project=shop"}}
+}
+
+// ListContainers lists the containers matching the options
+func (c *Client) ListContainers(ctx context.Context, options ListOptions) ([]Container, error) {
+	query := url.Values{}
+	if options.All {
+		query.Set("all", "1")
+	}
+	if len(options.Filters) > 0 {
+		filters, err := json.Marshal(options.Filters)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("filters", string(filters))
+	}
+
+	var containers []Container
+	if err := c.getJSON(ctx, "/containers/json", query, &containers); err != nil {
+		return nil, err
+	}
+	return containers, nil
+}
+
+// InspectContainer returns the low-level details of a container
+func (c *Client) InspectContainer(ctx context.Context, id string) (ContainerJSON, error) {
+	var container ContainerJSON
+	err := c.getJSON(ctx, "/containers/"+url.PathEscape(id)+"/json", nil, &container)
+	return container, err
+}
+
+// ContainerStats returns a single stats sample. The daemon waits for a second reading
+// so that PreCPUStats can be used to compute CPU usage.
+func (c *Client) ContainerStats(ctx context.Context, id string) (Stats, error) {
+	query := url.Values{}
+	query.Set("stream", "false")
+
+	var stats Stats
+	err := c.getJSON(ctx, "/containers/"+url.PathEscape(id)+"/stats", query, &stats)
+	return stats, err
+}
+
+// DiskUsage returns the space used by images, containers, volumes and the build cache.
+// The daemon computes sizes on request, which can take a while with many images.
+func (c *Client) DiskUsage(ctx context.Context) (DiskUsage, error) {
+	var usage DiskUsage
+	err := c.getJSON(ctx, "/system/df", nil, &usage)
+	return usage, err
+}
+
+// LogOptions selects the log lines returned by ContainerLogs
+type LogOptions struct {
+	Follow     bool
+	Tail       string // Number of lines from the end, or "all"
+	Since      time.Time
+	Until      time.Time
+	Timestamps bool
+}
+
+// ContainerLogs opens the stdout and stderr log stream of a container. Streams of containers
+// without a TTY are multiplexed; use ReadLogLines to split them. The caller closes the stream.
+func (c *Client) ContainerLogs(ctx context.Context, id string, options LogOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	query.Set("stdout", "1")
+	query.Set("stderr", "1")
+	if options.Follow {
+		query.Set("follow", "1")
+	}
+	if options.Tail != "" {
+		query.Set("tail", options.Tail)
+	}
+	if !options.Since.IsZero() {
+		query.Set("since", formatUnixTime(options.Since))
+	}
+	if !options.Until.IsZero() {
+		query.Set("until", formatUnixTime(options.Until))
+	}
+	if options.Timestamps {
+		query.Set("timestamps", "1")
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/containers/"+url.PathEscape(id)+"/logs", query)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// formatUnixTime formats a time as the fractional Unix timestamp the API expects
+func formatUnixTime(t time.Time) string {
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
+
+// getJSON performs a GET request and decodes the JSON response into out
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, out interface{}) error {
+	resp, err := c.do(ctx, http.MethodGet, path, query)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response from %s: %w", path, err)
+	}
+	return nil
+}
+
+// do sends a request and turns error status codes into APIErrors. The caller closes the body.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values) (*http.Response, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%w at %s: %v", ErrDaemonUnavailable, c.Host, err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+		// Errors are {"message": "..."}, but proxies may answer with plain text
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		message := strings.TrimSpace(string(body))
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			message = apiErr.Message
+		}
+		return nil, &APIError{StatusCode: resp.StatusCode, Message: message}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,288 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startFakeDaemon serves handler on a unix socket in a temporary directory and returns
+// a client connected to it
+func startFakeDaemon(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	// Socket paths are limited to about 100 characters, too short for t.TempDir on some systems
+	dir, err := os.MkdirTemp("", "whosay-docker")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socketPath := filepath.Join(dir, "docker.sock")
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := &http.Server{Handler: handler}
+	go server.Serve(listener)
+	t.Cleanup(func() { server.Close() })
+
+	client, err := NewClient("unix://" + socketPath)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+// writeJSON answers a request with a JSON body
+func writeJSON(t *testing.T, w http.ResponseWriter, value interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		t.Error(err)
+	}
+}
+
+// logFrame builds a frame of the multiplexed log format
+func logFrame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+// logLine is a line passed to the ReadLogLines callback
+type logLine struct {
+	stream, line string
+}
+
+func collectLogLines(t *testing.T, data []byte, tty bool) []logLine {
+	t.Helper()
+	lines := []logLine{}
+	err := ReadLogLines(bytes.NewReader(data), tty, func(stream, line string) error {
+		lines = append(lines, logLine{stream, line})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadLogLines: %v", err)
+	}
+	return lines
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		host    string
+		baseURL string
+		wantErr bool
+	}{
+		{host: "unix:///var/run/docker.sock", baseURL: "http://docker"},
+		{host: "tcp://127.0.0.1:2375", baseURL: "http://127.0.0.1:2375"},
+		{host: "https://docker.example.com:2376", baseURL: "https://docker.example.com:2376"},
+		{host: "npipe:////./pipe/docker_engine", wantErr: true},
+		{host: "ssh://user@host", wantErr: true},
+		{host: "ftp://host", wantErr: true},
+	}
+
+	t.Setenv("DOCKER_TLS_VERIFY", "")
+	for _, test := range tests {
+		client, err := NewClient(test.host)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("NewClient(%q) succeeded, want an error", test.host)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NewClient(%q): %v", test.host, err)
+			continue
+		}
+		if client.baseURL != test.baseURL {
+			t.Errorf("NewClient(%q) base URL = %q, want %q", test.host, client.baseURL, test.baseURL)
+		}
+	}
+}
+
+func TestNewClientTLSFromEnv(t *testing.T) {
+	t.Setenv("DOCKER_TLS_VERIFY", "1")
+	t.Setenv("DOCKER_CERT_PATH", t.TempDir())
+
+	client, err := NewClient("tcp://127.0.0.1:2376")
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	if client.baseURL != "https://127.0.0.1:2376" {
+		t.Errorf("base URL = %q, want https", client.baseURL)
+	}
+
+	transport := client.httpClient.Transport.(*http.Transport)
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.InsecureSkipVerify {
+		t.Errorf("TLS config = %+v, want verification enabled", transport.TLSClientConfig)
+	}
+
+	// A CA file without certificates is an error rather than an unverified connection
+	if err := os.WriteFile(filepath.Join(os.Getenv("DOCKER_CERT_PATH"), "ca.pem"), []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewClient("tcp://127.0.0.1:2376"); err == nil {
+		t.Error("NewClient succeeded with an invalid ca.pem")
+	}
+}
+
+func TestListContainers(t *testing.T) {
+	client := startFakeDaemon(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/json" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("all") != "1" {
+			t.Errorf("all = %q, want 1", r.URL.Query().Get("all"))
+		}
+
+		var filters map[string][]string
+		if err := json.Unmarshal([]byte(r.URL.Query().Get("filters")), &filters); err != nil {
+			t.Errorf("filters: %v", err)
+		}
+		want := map[string][]string{"label": {"com.docker.compose.project=shop"}}
+		if !reflect.DeepEqual(filters, want) {
+			t.Errorf("filters = %v, want %v", filters, want)
+		}
+
+		writeJSON(t, w, []Container{
+			{ID: "abc123", Names: []string{"/shop-web-1"}, Image: "nginx", State: "running"},
+		})
+	}))
+
+	containers, err := client.ListContainers(context.Background(), ListOptions{
+		All:     true,
+		Filters: map[string][]string{"label": {"com.docker.compose.project=shop"}},
+	})
+	if err != nil {
+		t.Fatalf("ListContainers: %v", err)
+	}
+	if len(containers) != 1 || containers[0].ID != "abc123" || containers[0].Names[0] != "/shop-web-1" {
+		t.Errorf("containers = %+v", containers)
+	}
+}
+
+func TestContainerStats(t *testing.T) {
+	client := startFakeDaemon(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/containers/abc123/stats" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.URL.Query().Get("stream") != "false" {
+			t.Errorf("stream = %q, want false", r.URL.Query().Get("stream"))
+		}
+		w.Write([]byte(`{
+			"cpu_stats": {"cpu_usage": {"total_usage": 2000}, "system_cpu_usage": 20000, "online_cpus": 4},
+			"precpu_stats": {"cpu_usage": {"total_usage": 1000}, "system_cpu_usage": 10000},
+			"memory_stats": {"usage": 1048576, "limit": 4194304, "stats": {"inactive_file": 4096}}
+		}`))
+	}))
+
+	stats, err := client.ContainerStats(context.Background(), "abc123")
+	if err != nil {
+		t.Fatalf("ContainerStats: %v", err)
+	}
+	if stats.CPUStats.CPUUsage.TotalUsage != 2000 || stats.PreCPUStats.SystemUsage != 10000 || stats.CPUStats.OnlineCPUs != 4 {
+		t.Errorf("CPU stats = %+v / %+v", stats.CPUStats, stats.PreCPUStats)
+	}
+	if stats.MemoryStats.Usage != 1048576 || stats.MemoryStats.Limit != 4194304 || stats.MemoryStats.Stats["inactive_file"] != 4096 {
+		t.Errorf("memory stats = %+v", stats.MemoryStats)
+	}
+}
+
+func TestInspectContainerNotFound(t *testing.T) {
+	client := startFakeDaemon(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "No such container: missing"}`))
+	}))
+
+	_, err := client.InspectContainer(context.Background(), "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v, want a not found error", err)
+	}
+	if !strings.Contains(err.Error(), "No such container: missing") {
+		t.Errorf("err = %q, want the daemon's message", err)
+	}
+}
+
+func TestDaemonUnavailable(t *testing.T) {
+	client, err := NewClient("unix://" + filepath.Join(t.TempDir(), "missing.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx); err == nil || !strings.Contains(err.Error(), ErrDaemonUnavailable.Error()) {
+		t.Errorf("Ping = %v, want %v", err, ErrDaemonUnavailable)
+	}
+}
+
+func TestReadLogLinesMultiplexed(t *testing.T) {
+	var stream []byte
+	stream = append(stream, logFrame(streamStdout, "first line\nsecond ")...)
+	stream = append(stream, logFrame(streamStderr, "an error\r\n")...)
+	stream = append(stream, logFrame(streamStdout, "line\nunterminated")...)
+
+	got := collectLogLines(t, stream, false)
+	want := []logLine{
+		{"stdout", "first line"},
+		{"stderr", "an error"},
+		{"stdout", "second line"},
+		{"stdout", "unterminated"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
+
+func TestReadLogLinesTTY(t *testing.T) {
+	got := collectLogLines(t, []byte("one\r\ntwo\nthree"), true)
+	want := []logLine{{"stdout", "one"}, {"stdout", "two"}, {"stdout", "three"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
+
+func TestContainerLogsOverSocket(t *testing.T) {
+	client := startFakeDaemon(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("stdout") != "1" || query.Get("stderr") != "1" || query.Get("tail") != "2" {
+			t.Errorf("query = %v", query)
+		}
+		w.Write(logFrame(streamStdout, "hello\n"))
+		w.Write(logFrame(streamStderr, "oops\n"))
+	}))
+
+	logs, err := client.ContainerLogs(context.Background(), "abc123", LogOptions{Tail: "2"})
+	if err != nil {
+		t.Fatalf("ContainerLogs: %v", err)
+	}
+	defer logs.Close()
+
+	got := []logLine{}
+	err = ReadLogLines(logs, false, func(stream, line string) error {
+		got = append(got, logLine{stream, line})
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadLogLines: %v", err)
+	}
+	want := []logLine{{"stdout", "hello"}, {"stderr", "oops"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("lines = %v, want %v", got, want)
+	}
+}
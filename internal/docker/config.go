@@ -0,0 +1,150 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// dockerContext is a daemon endpoint saved with `docker context create`
+type dockerContext struct {
+	name      string
+	host      string
+	tlsConfig *tls.Config
+}
+
+// contextMeta is the meta.json of a docker context
+type contextMeta struct {
+	Endpoints map[string]struct {
+		Host          string `json:"Host"`
+		SkipTLSVerify bool   `json:"SkipTLSVerify"`
+	} `json:"Endpoints"`
+}
+
+// configDir returns DOCKER_CONFIG, or ~/.docker
+func configDir() string {
+	if dir := os.Getenv("DOCKER_CONFIG"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ".docker"
+	}
+	return filepath.Join(home, ".docker")
+}
+
+// currentContextName returns DOCKER_CONTEXT, or the context selected with `docker context use`
+func currentContextName() string {
+	if name := os.Getenv("DOCKER_CONTEXT"); name != "" {
+		return name
+	}
+
+	data, err := os.ReadFile(filepath.Join(configDir(), "config.json"))
+	if err != nil {
+		return ""
+	}
+	var config struct {
+		CurrentContext string `json:"currentContext"`
+	}
+	if json.Unmarshal(data, &config) != nil {
+		return ""
+	}
+	return config.CurrentContext
+}
+
+// ContextSelected reports whether a docker context other than the default one is in use
+func ContextSelected() bool {
+	name := currentContextName()
+	return name != "" && name != "default"
+}
+
+// currentContext loads the selected docker context, or returns nil for the default one.
+// Contexts are stored under the SHA-256 of their name, with their TLS files kept apart.
+func currentContext() (*dockerContext, error) {
+	if !ContextSelected() {
+		return nil, nil
+	}
+	name := currentContextName()
+
+	digest := sha256.Sum256([]byte(name))
+	id := hex.EncodeToString(digest[:])
+
+	data, err := os.ReadFile(filepath.Join(configDir(), "contexts", "meta", id, "meta.json"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("docker context '%s' not found", name)
+		}
+		return nil, fmt.Errorf("failed to read docker context '%s': %w", name, err)
+	}
+
+	var meta contextMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse docker context '%s': %w", name, err)
+	}
+	endpoint, ok := meta.Endpoints["docker"]
+	if !ok || endpoint.Host == "" {
+		return nil, fmt.Errorf("docker context '%s' has no docker endpoint", name)
+	}
+
+	found := &dockerContext{name: name, host: endpoint.Host}
+	tlsDir := filepath.Join(configDir(), "contexts", "tls", id, "docker")
+	if _, err := os.Stat(tlsDir); err == nil {
+		found.tlsConfig, err = loadTLSConfig(tlsDir, !endpoint.SkipTLSVerify)
+		if err != nil {
+			return nil, fmt.Errorf("docker context '%s': %w", name, err)
+		}
+	}
+	return found, nil
+}
+
+// tlsConfigFromEnv returns the TLS settings of DOCKER_TLS_VERIFY and DOCKER_CERT_PATH,
+// or nil when TLS isn't enabled
+func tlsConfigFromEnv() (*tls.Config, error) {
+	if os.Getenv("DOCKER_TLS_VERIFY") == "" {
+		return nil, nil
+	}
+
+	certPath := os.Getenv("DOCKER_CERT_PATH")
+	if certPath == "" {
+		certPath = configDir()
+	}
+	return loadTLSConfig(certPath, true)
+}
+
+// loadTLSConfig reads ca.pem, cert.pem and key.pem from a directory, as written by
+// docker-machine and `docker context create`. Each file is optional; without ca.pem the
+// system's CAs are trusted.
+func loadTLSConfig(dir string, verify bool) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: !verify,
+	}
+
+	caFile := filepath.Join(dir, "ca.pem")
+	if data, err := os.ReadFile(caFile); err == nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		config.RootCAs = pool
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if _, err := os.Stat(certFile); err == nil {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Event is a message of GET /events
+type Event struct {
+	Type     string `json:"Type"`
+	Action   string `json:"Action"`
+	Actor    Actor  `json:"Actor"`
+	Scope    string `json:"scope"`
+	Time     int64  `json:"time"`
+	TimeNano int64  `json:"timeNano"`
+}
+
+// Actor is the object an event is about, such as a container or an image
+type Actor struct {
+	ID         string            `json:"ID"`
+	Attributes map[string]string `json:"Attributes"`
+}
+
+// EventOptions selects the events returned by Events
+type EventOptions struct {
+	Since   time.Time
+	Until   time.Time
+	Filters map[string][]string // e.g. {"type": {"container"}, "event": {"die", "oom"}}
+}
+
+// Events opens the event stream. Without Until the stream stays open and reports new
+// events as they happen. The caller closes the stream; use ReadEvents to decode it.
+func (c *Client) Events(ctx context.Context, options EventOptions) (io.ReadCloser, error) {
+	query := url.Values{}
+	if !options.Since.IsZero() {
+		query.Set("since", formatUnixTime(options.Since))
+	}
+	if !options.Until.IsZero() {
+		query.Set("until", formatUnixTime(options.Until))
+	}
+	if len(options.Filters) > 0 {
+		filters, err := json.Marshal(options.Filters)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("filters", string(filters))
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, "/events", query)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// ReadEvents decodes the event stream, a sequence of JSON objects, and calls fn for each
+// event until the stream ends or fn returns an error
+func ReadEvents(r io.Reader, fn func(Event) error) error {
+	decoder := json.NewDecoder(r)
+	for {
+		var event Event
+		if err := decoder.Decode(&event); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+}
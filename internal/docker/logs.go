@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+// Stream type bytes of the multiplexed log format
+const (
+	streamStdout = 1
+	streamStderr = 2
+)
+
+// ReadLogLines splits a log stream into lines and calls fn with the stream name ("stdout"
+// or "stderr") and the line without its newline. Containers without a TTY send 8 byte
+// frame headers (stream type, 3 zero bytes, big-endian payload size) before each chunk.
+func ReadLogLines(r io.Reader, tty bool, fn func(stream, line string) error) error {
+	if tty {
+		return readRawLines(bufio.NewReader(r), "stdout", fn)
+	}
+
+	reader := bufio.NewReader(r)
+	partial := map[string]string{}
+	header := make([]byte, 8)
+
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return flushPartialLines(partial, fn)
+			}
+			return err
+		}
+
+		stream := "stdout"
+		if header[0] == streamStderr {
+			stream = "stderr"
+		}
+
+		payload := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				return flushPartialLines(partial, fn)
+			}
+			return err
+		}
+
+		// A frame may end mid-line, keep the remainder until the next frame of the stream
+		data := partial[stream] + string(payload)
+		lines := strings.Split(data, "\n")
+		partial[stream] = lines[len(lines)-1]
+
+		for _, line := range lines[:len(lines)-1] {
+			if err := fn(stream, strings.TrimSuffix(line, "\r")); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readRawLines reads newline separated lines from a TTY stream
+func readRawLines(reader *bufio.Reader, stream string, fn func(stream, line string) error) error {
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if cbErr := fn(stream, strings.TrimRight(line, "\r\n")); cbErr != nil {
+				return cbErr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// flushPartialLines passes on unterminated lines left at the end of a stream
+func flushPartialLines(partial map[string]string, fn func(stream, line string) error) error {
+	for _, stream := range []string{"stdout", "stderr"} {
+		if partial[stream] != "" {
+			if err := fn(stream, partial[stream]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
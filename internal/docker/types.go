@@ -0,0 +1,164 @@
+package docker
+
+// The types below cover the parts of the Engine API responses whosay uses
+
+// Container is an entry of GET /containers/json
+type Container struct {
+	ID      string            `json:"Id"`
+	Names   []string          `json:"Names"`
+	Image   string            `json:"Image"`
+	Command string            `json:"Command"`
+	Created int64             `json:"Created"`
+	State   string            `json:"State"`
+	Status  string            `json:"Status"`
+	Ports   []Port            `json:"Ports"`
+	Labels  map[string]string `json:"Labels"`
+}
+
+// Port is a port mapping of a listed container
+type Port struct {
+	IP          string `json:"IP"`
+	PrivatePort int    `json:"PrivatePort"`
+	PublicPort  int    `json:"PublicPort"`
+	Type        string `json:"Type"`
+}
+
+// ContainerJSON is the response of GET /containers/{id}/json
+type ContainerJSON struct {
+	ID              string          `json:"Id"`
+	Name            string          `json:"Name"`
+	Created         string          `json:"Created"`
+	RestartCount    int             `json:"RestartCount"`
+	State           ContainerState  `json:"State"`
+	Config          ContainerConfig `json:"Config"`
+	NetworkSettings NetworkSettings `json:"NetworkSettings"`
+}
+
+// ContainerState is the runtime state of an inspected container
+type ContainerState struct {
+	Status     string  `json:"Status"`
+	Running    bool    `json:"Running"`
+	Paused     bool    `json:"Paused"`
+	Restarting bool    `json:"Restarting"`
+	OOMKilled  bool    `json:"OOMKilled"`
+	Dead       bool    `json:"Dead"`
+	Pid        int     `json:"Pid"`
+	ExitCode   int     `json:"ExitCode"`
+	Error      string  `json:"Error"`
+	StartedAt  string  `json:"StartedAt"`
+	FinishedAt string  `json:"FinishedAt"`
+	Health     *Health `json:"Health"`
+}
+
+// Health is the health check state of a container
+type Health struct {
+	Status        string      `json:"Status"`
+	FailingStreak int         `json:"FailingStreak"`
+	Log           []HealthLog `json:"Log"`
+}
+
+// HealthLog is the result of a single health check run
+type HealthLog struct {
+	Start    string `json:"Start"`
+	End      string `json:"End"`
+	ExitCode int    `json:"ExitCode"`
+	Output   string `json:"Output"`
+}
+
+// ContainerConfig is the configuration of an inspected container
+type ContainerConfig struct {
+	Image  string            `json:"Image"`
+	Tty    bool              `json:"Tty"`
+	Labels map[string]string `json:"Labels"`
+}
+
+// NetworkSettings holds the networks a container is attached to
+type NetworkSettings struct {
+	Networks map[string]EndpointSettings `json:"Networks"`
+}
+
+// EndpointSettings is a container's attachment to one network
+type EndpointSettings struct {
+	IPAddress string `json:"IPAddress"`
+}
+
+// Stats is the response of GET /containers/{id}/stats?stream=false
+type Stats struct {
+	Read        string      `json:"read"`
+	CPUStats    CPUStats    `json:"cpu_stats"`
+	PreCPUStats CPUStats    `json:"precpu_stats"`
+	MemoryStats MemoryStats `json:"memory_stats"`
+}
+
+// CPUStats holds cumulative CPU usage in nanoseconds
+type CPUStats struct {
+	CPUUsage struct {
+		TotalUsage  uint64   `json:"total_usage"`
+		PercpuUsage []uint64 `json:"percpu_usage"`
+	} `json:"cpu_usage"`
+	SystemUsage uint64 `json:"system_cpu_usage"`
+	OnlineCPUs  int    `json:"online_cpus"`
+}
+
+// MemoryStats holds memory usage in bytes
+type MemoryStats struct {
+	Usage uint64            `json:"usage"`
+	Limit uint64            `json:"limit"`
+	Stats map[string]uint64 `json:"stats"`
+}
+
+// DiskUsage is the response of GET /system/df
+type DiskUsage struct {
+	LayersSize int64                `json:"LayersSize"`
+	Images     []ImageSummary       `json:"Images"`
+	Containers []ContainerDiskUsage `json:"Containers"`
+	Volumes    []Volume             `json:"Volumes"`
+	BuildCache []BuildCache         `json:"BuildCache"`
+}
+
+// ImageSummary is an image entry of the disk usage report. Sizes are -1 when unknown.
+type ImageSummary struct {
+	ID         string   `json:"Id"`
+	RepoTags   []string `json:"RepoTags"`
+	Created    int64    `json:"Created"`
+	Size       int64    `json:"Size"`
+	SharedSize int64    `json:"SharedSize"`
+	Containers int64    `json:"Containers"`
+}
+
+// ContainerDiskUsage is a container entry of the disk usage report
+type ContainerDiskUsage struct {
+	ID     string   `json:"Id"`
+	Names  []string `json:"Names"`
+	Image  string   `json:"Image"`
+	State  string   `json:"State"`
+	SizeRw int64    `json:"SizeRw"`
+}
+
+// Volume is a volume entry of the disk usage report
+type Volume struct {
+	Name      string           `json:"Name"`
+	Driver    string           `json:"Driver"`
+	CreatedAt string           `json:"CreatedAt"`
+	UsageData *VolumeUsageData `json:"UsageData"`
+}
+
+// VolumeUsageData holds the size of a volume and the number of containers using it.
+// Both are -1 when the driver can't report them.
+type VolumeUsageData struct {
+	Size     int64 `json:"Size"`
+	RefCount int64 `json:"RefCount"`
+}
+
+// BuildCache is a build cache record of the disk usage report
+type BuildCache struct {
+	ID          string `json:"ID"`
+	Type        string `json:"Type"`
+	Description string `json:"Description"`
+	InUse       bool   `json:"InUse"`
+	Shared      bool   `json:"Shared"`
+	Size        int64  `json:"Size"`
+	CreatedAt   string `json:"CreatedAt"`
+	LastUsedAt  string `json:"LastUsedAt"`
+	UsageCount  int    `json:"UsageCount"`
+}
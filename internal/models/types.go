@@ -8,6 +8,10 @@ type Options struct {
 	VerboseOutput bool
 	CompactMode   bool
 	EnableAlerts  bool
+	Process       ProcessDisplay
+	Docker        DockerDisplay
+	Logs          LogDisplay
+	Events        EventDisplay
 }
 
 type SystemInfo struct {
@@ -25,22 +29,101 @@ type SystemInfo struct {
 }
 
 type CPUInfo struct {
-	NumCPU       int     `json:"num_cpu"`
-	Usage        float64 `json:"usage_percent"`
-	Architecture string  `json:"architecture"`
+	NumCPU       int                `json:"num_cpu"`
+	Usage        float64            `json:"usage_percent"`
+	User         float64            `json:"user_percent"`
+	System       float64            `json:"system_percent"`
+	IOWait       float64            `json:"iowait_percent"`
+	Steal        float64            `json:"steal_percent"`
+	Idle         float64            `json:"idle_percent"`
+	Architecture string             `json:"architecture"`
+	Cores        []CPUCoreInfo      `json:"cores,omitempty"`
+	Container    *ContainerCPUUsage `json:"container,omitempty"`
+}
+
+type ContainerCPUUsage struct {
+	Runtime          string  `json:"runtime"`
+	LimitCores       float64 `json:"limit_cores,omitempty"`
+	UsageCores       float64 `json:"usage_cores"`
+	LimitPerc        float64 `json:"limit_usage_percent,omitempty"`
+	ThrottledPercent float64 `json:"throttled_percent"`
+}
+
+type CPUCoreInfo struct {
+	ID     int     `json:"id"`
+	Usage  float64 `json:"usage_percent"`
+	User   float64 `json:"user_percent"`
+	System float64 `json:"system_percent"`
+	IOWait float64 `json:"iowait_percent"`
+	Steal  float64 `json:"steal_percent"`
+	Idle   float64 `json:"idle_percent"`
 }
 
 type MemoryInfo struct {
-	Total     uint64  `json:"total_bytes"`
+	Total     uint64                `json:"total_bytes"`
+	Used      uint64                `json:"used_bytes"`
+	Free      uint64                `json:"free_bytes"`
+	Available uint64                `json:"available_bytes"`
+	Buffers   uint64                `json:"buffers_bytes,omitempty"`
+	Cached    uint64                `json:"cached_bytes,omitempty"`
+	Shared    uint64                `json:"shared_bytes,omitempty"`
+	Slab      uint64                `json:"slab_bytes,omitempty"`
+	Dirty     uint64                `json:"dirty_bytes,omitempty"`
+	Writeback uint64                `json:"writeback_bytes,omitempty"`
+	UsagePerc float64               `json:"usage_percent"`
+	Swap      SwapInfo              `json:"swap"`
+	Container *ContainerMemoryUsage `json:"container,omitempty"`
+}
+
+type ContainerMemoryUsage struct {
+	Runtime   string  `json:"runtime"`
+	Limit     uint64  `json:"limit_bytes,omitempty"`
 	Used      uint64  `json:"used_bytes"`
-	UsagePerc float64 `json:"usage_percent"`
+	LimitPerc float64 `json:"limit_usage_percent,omitempty"`
+}
+
+type SwapInfo struct {
+	Total       uint64       `json:"total_bytes"`
+	Used        uint64       `json:"used_bytes"`
+	Free        uint64       `json:"free_bytes"`
+	UsagePerc   float64      `json:"usage_percent"`
+	SwapInRate  float64      `json:"swap_in_bytes_per_sec"`
+	SwapOutRate float64      `json:"swap_out_bytes_per_sec"`
+	ZswapPool   uint64       `json:"zswap_pool_bytes,omitempty"`
+	ZswapStored uint64       `json:"zswap_stored_bytes,omitempty"`
+	Devices     []SwapDevice `json:"devices,omitempty"`
+	Zram        []ZramDevice `json:"zram,omitempty"`
+}
+
+type SwapDevice struct {
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Size     uint64 `json:"size_bytes"`
+	Used     uint64 `json:"used_bytes"`
+	Priority int    `json:"priority"`
+}
+
+type ZramDevice struct {
+	Name             string  `json:"name"`
+	OrigDataSize     uint64  `json:"orig_data_bytes"`
+	ComprDataSize    uint64  `json:"compr_data_bytes"`
+	MemUsedTotal     uint64  `json:"mem_used_bytes"`
+	CompressionRatio float64 `json:"compression_ratio"`
 }
 
 type DiskInfo struct {
-	Path       string  `json:"path"`
-	Total      uint64  `json:"total_bytes"`
-	Free       uint64  `json:"free_bytes"`
-	UsagePerc  float64 `json:"usage_percent"`
+	Path            string  `json:"path"`
+	Device          string  `json:"device"`
+	FSType          string  `json:"fs_type"`
+	Options         string  `json:"mount_options,omitempty"`
+	Total           uint64  `json:"total_bytes"`
+	Used            uint64  `json:"used_bytes"`
+	Free            uint64  `json:"free_bytes"`
+	UsagePerc       float64 `json:"usage_percent"`
+	InodesTotal     uint64  `json:"inodes_total,omitempty"`
+	InodesUsed      uint64  `json:"inodes_used,omitempty"`
+	InodesFree      uint64  `json:"inodes_free,omitempty"`
+	InodesUsagePerc float64 `json:"inodes_usage_percent,omitempty"`
 }
 
 type NetworkInfo struct {
@@ -71,30 +154,315 @@ type ProcessInfo struct {
 	Status      string    `json:"status,omitempty"`
 	StartTime   time.Time `json:"start_time,omitempty"`
 	CommandLine string    `json:"command_line,omitempty"`
+	Threads     int       `json:"threads,omitempty"`
+	IOReadRate  float64   `json:"io_read_bytes_per_sec"`
+	IOWriteRate float64   `json:"io_write_bytes_per_sec"`
+	Sockets     int       `json:"sockets,omitempty"`
+}
+
+type ProcessDetail struct {
+	ProcessInfo
+	Exe         string            `json:"exe,omitempty"`
+	Cwd         string            `json:"cwd,omitempty"`
+	Nice        int               `json:"nice"`
+	OpenFDs     int               `json:"open_fds"`
+	FDLimit     uint64            `json:"fd_limit_soft"`
+	Limits      []ProcessLimit    `json:"limits,omitempty"`
+	MemoryMaps  ProcessMemoryMaps `json:"memory_maps"`
+	IO          *ProcessIO        `json:"io,omitempty"`
+	Cgroup      string            `json:"cgroup,omitempty"`
+	Namespaces  map[string]string `json:"namespaces,omitempty"`
+	Children    []ProcessInfo     `json:"children,omitempty"`
+	Environment []string          `json:"environment,omitempty"`
+	Unavailable []string          `json:"unavailable,omitempty"`
+}
+
+type ProcessLimit struct {
+	Name  string `json:"name"`
+	Soft  string `json:"soft"`
+	Hard  string `json:"hard"`
+	Units string `json:"units,omitempty"`
+}
+
+type ProcessMemoryMaps struct {
+	RSS          uint64 `json:"rss_bytes"`
+	PSS          uint64 `json:"pss_bytes"`
+	SharedClean  uint64 `json:"shared_clean_bytes"`
+	SharedDirty  uint64 `json:"shared_dirty_bytes"`
+	PrivateClean uint64 `json:"private_clean_bytes"`
+	PrivateDirty uint64 `json:"private_dirty_bytes"`
+	Swap         uint64 `json:"swap_bytes"`
+}
+
+type ProcessIO struct {
+	ReadChars           uint64 `json:"rchar"`
+	WriteChars          uint64 `json:"wchar"`
+	ReadSyscalls        uint64 `json:"syscr"`
+	WriteSyscalls       uint64 `json:"syscw"`
+	ReadBytes           uint64 `json:"read_bytes"`
+	WriteBytes          uint64 `json:"write_bytes"`
+	CancelledWriteBytes uint64 `json:"cancelled_write_bytes"`
 }
 
 type ProcessDisplay struct {
-	SortBy    string
-	Ascending bool
-	Filter    string
-	Limit     int
+	SortBy     string
+	Ascending  bool
+	Filter     string
+	Limit      int
+	User       string
+	Tree       bool
+	Collapse   []int
+	GroupBy    string
+	StuckAfter time.Duration
+}
+
+type ProcessGroup struct {
+	Key       string  `json:"key"`
+	Processes int     `json:"processes"`
+	Threads   int     `json:"threads"`
+	CPU       float64 `json:"cpu_percent"`
+	Memory    float64 `json:"memory_percent"`
+	MemoryRSS uint64  `json:"memory_rss_kb"`
+	Users     int     `json:"users,omitempty"`
+}
+
+type ProcessAggregate struct {
+	GroupBy string         `json:"group_by"`
+	Groups  []ProcessGroup `json:"groups"`
+}
+
+type ProcessActionResult struct {
+	PID     int    `json:"pid"`
+	Name    string `json:"name"`
+	Action  string `json:"action"`
+	Detail  string `json:"detail,omitempty"`
+	DryRun  bool   `json:"dry_run,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ProcessIssue struct {
+	Type       string  `json:"type"`
+	PID        int     `json:"pid"`
+	Name       string  `json:"name"`
+	PPID       int     `json:"parent_pid,omitempty"`
+	ParentName string  `json:"parent_name,omitempty"`
+	Duration   float64 `json:"duration_seconds,omitempty"`
+	OpenFDs    int     `json:"open_fds,omitempty"`
+	FDLimit    uint64  `json:"fd_limit,omitempty"`
+	Detail     string  `json:"detail"`
+}
+
+type ProcessHealth struct {
+	Checked         int            `json:"checked"`
+	Zombies         int            `json:"zombies"`
+	Uninterruptible int            `json:"uninterruptible"`
+	Stuck           int            `json:"stuck"`
+	NearFDLimit     int            `json:"near_fd_limit"`
+	Issues          []ProcessIssue `json:"issues"`
+}
+
+type ProcessTreeNode struct {
+	ProcessInfo
+	Children    []*ProcessTreeNode `json:"children,omitempty"`
+	TreeCPU     float64            `json:"tree_cpu_percent"`
+	TreeMemory  float64            `json:"tree_memory_percent"`
+	TreeRSS     uint64             `json:"tree_memory_rss_kb"`
+	Descendants int                `json:"descendants"`
+	Collapsed   bool               `json:"collapsed,omitempty"`
 }
 
 type ContainerInfo struct {
+	ID            string              `json:"id"`
+	Name          string              `json:"name"`
+	Image         string              `json:"image"`
+	Command       string              `json:"command,omitempty"`
+	Status        string              `json:"status"`
+	State         string              `json:"state"`
+	CreatedAt     time.Time           `json:"created_at,omitempty"`
+	StartedAt     time.Time           `json:"started_at,omitempty"`
+	IPAddress     string              `json:"ip_address,omitempty"`
+	Ports         []string            `json:"ports,omitempty"`
+	CPUPercent    float64             `json:"cpu_percent,omitempty"`
+	MemoryUsage   uint64              `json:"memory_usage_bytes,omitempty"`
+	MemoryLimit   uint64              `json:"memory_limit_bytes,omitempty"`
+	MemoryPerc    float64             `json:"memory_percent,omitempty"`
+	ExitCode      int                 `json:"exit_code"`
+	OOMKilled     bool                `json:"oom_killed"`
+	RestartCount  int                 `json:"restart_count"`
+	FinishedAt    time.Time           `json:"finished_at,omitempty"`
+	Health        string              `json:"health,omitempty"`
+	Project       string              `json:"project,omitempty"`
+	Service       string              `json:"service,omitempty"`
+	HealthLog     []HealthCheckResult `json:"health_log,omitempty"`
+	FailingStreak int                 `json:"health_failing_streak,omitempty"`
+	Runtime       string              `json:"runtime,omitempty"`
+	PodUID        string              `json:"pod_uid,omitempty"`
+	Cgroup        *CgroupStats        `json:"cgroup,omitempty"`
+}
+
+type CgroupStats struct {
+	Path             string  `json:"path"`
+	CPUUsageUsec     uint64  `json:"cpu_usage_usec"`
+	CPUPercent       float64 `json:"cpu_percent"`
+	CPULimit         float64 `json:"cpu_limit_cores,omitempty"`
+	Periods          uint64  `json:"cpu_periods"`
+	ThrottledPeriods uint64  `json:"cpu_throttled_periods"`
+	ThrottledUsec    uint64  `json:"cpu_throttled_usec"`
+	ThrottledPercent float64 `json:"cpu_throttled_percent"`
+	MemoryCurrent    uint64  `json:"memory_current_bytes"`
+	MemoryMax        uint64  `json:"memory_max_bytes,omitempty"`
+	OOMEvents        uint64  `json:"oom_events"`
+	OOMKills         uint64  `json:"oom_kills"`
+	IOReadBytes      uint64  `json:"io_read_bytes"`
+	IOWriteBytes     uint64  `json:"io_write_bytes"`
+	PIDs             uint64  `json:"pids"`
+	PIDsMax          uint64  `json:"pids_max,omitempty"`
+}
+
+type HealthCheckResult struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	ExitCode int       `json:"exit_code"`
+	Output   string    `json:"output"`
+}
+
+type ContainerIssue struct {
+	Type           string   `json:"type"`
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Health         string   `json:"health,omitempty"`
+	PreviousHealth string   `json:"previous_health,omitempty"`
+	RestartCount   int      `json:"restart_count"`
+	Restarts       int      `json:"restarts_in_window,omitempty"`
+	FailingOutput  []string `json:"failing_output,omitempty"`
+	Detail         string   `json:"detail"`
+}
+
+type ContainerHealth struct {
+	Checked       int              `json:"checked"`
+	Unhealthy     int              `json:"unhealthy"`
+	RestartLoops  int              `json:"restart_loops"`
+	HealthChanges int              `json:"health_changes"`
+	Issues        []ContainerIssue `json:"issues"`
+}
+
+type ComposeProject struct {
+	Name        string           `json:"name"`
+	Services    []ComposeService `json:"services"`
+	Running     int              `json:"running"`
+	Desired     int              `json:"desired"`
+	CPUPercent  float64          `json:"cpu_percent"`
+	MemoryUsage uint64           `json:"memory_usage_bytes"`
+	Unhealthy   []string         `json:"unhealthy_services,omitempty"`
+}
+
+type ComposeService struct {
+	Name        string   `json:"name"`
+	Running     int      `json:"running"`
+	Desired     int      `json:"desired"`
+	CPUPercent  float64  `json:"cpu_percent"`
+	MemoryUsage uint64   `json:"memory_usage_bytes"`
+	Health      string   `json:"health,omitempty"`
+	Containers  []string `json:"containers"`
+}
+
+type DockerDiskUsage struct {
+	Summary     []DockerDiskUsageSummary `json:"summary"`
+	Images      []DockerImageUsage       `json:"images"`
+	Containers  []DockerContainerUsage   `json:"containers"`
+	Volumes     []DockerVolumeUsage      `json:"volumes"`
+	BuildCache  []DockerBuildCacheUsage  `json:"build_cache"`
+	Reclaimable uint64                   `json:"reclaimable_bytes"`
+}
+
+type DockerDiskUsageSummary struct {
+	Type        string `json:"type"`
+	Total       int    `json:"total"`
+	Active      int    `json:"active"`
+	Size        uint64 `json:"size_bytes"`
+	Reclaimable uint64 `json:"reclaimable_bytes"`
+}
+
+type DockerImageUsage struct {
+	ID         string    `json:"id"`
+	Repository string    `json:"repository"`
+	Tag        string    `json:"tag"`
+	Size       uint64    `json:"size_bytes"`
+	SharedSize uint64    `json:"shared_size_bytes"`
+	UniqueSize uint64    `json:"unique_size_bytes"`
+	Containers int       `json:"containers"`
+	Dangling   bool      `json:"dangling"`
+	Created    time.Time `json:"created"`
+}
+
+type DockerContainerUsage struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	State string `json:"state"`
+	Size  uint64 `json:"size_bytes"`
+}
+
+type DockerVolumeUsage struct {
+	Name      string    `json:"name"`
+	Driver    string    `json:"driver"`
+	Size      uint64    `json:"size_bytes"`
+	Links     int       `json:"links"`
+	CreatedAt time.Time `json:"created_at,omitempty"`
+}
+
+type DockerBuildCacheUsage struct {
 	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Image       string    `json:"image"`
-	Command     string    `json:"command,omitempty"`
-	Status      string    `json:"status"`
-	State       string    `json:"state"`
+	Type        string    `json:"type"`
+	Description string    `json:"description,omitempty"`
+	Size        uint64    `json:"size_bytes"`
+	InUse       bool      `json:"in_use"`
+	Shared      bool      `json:"shared"`
+	UsageCount  int       `json:"usage_count"`
 	CreatedAt   time.Time `json:"created_at,omitempty"`
-	StartedAt   time.Time `json:"started_at,omitempty"`
-	IPAddress   string    `json:"ip_address,omitempty"`
-	Ports       []string  `json:"ports,omitempty"`
-	CPUPercent  float64   `json:"cpu_percent,omitempty"`
-	MemoryUsage uint64    `json:"memory_usage_bytes,omitempty"`
-	MemoryLimit uint64    `json:"memory_limit_bytes,omitempty"`
-	MemoryPerc  float64   `json:"memory_percent,omitempty"`
+	LastUsedAt  time.Time `json:"last_used_at,omitempty"`
+}
+
+type DockerDisplay struct {
+	All           bool
+	Project       string
+	RestartLoop   int
+	RestartWindow time.Duration
+}
+
+type LogDisplay struct {
+	Limit   int
+	Follow  bool
+	Since   time.Time
+	Until   time.Time
+	Include string
+	Exclude string
+	NDJSON  bool
+}
+
+type EventDisplay struct {
+	Since   time.Time
+	Until   time.Time
+	Filters map[string][]string
+}
+
+type DockerEvent struct {
+	Time       time.Time         `json:"time"`
+	Type       string            `json:"type"`
+	Action     string            `json:"action"`
+	Detail     string            `json:"detail,omitempty"`
+	ID         string            `json:"id"`
+	Name       string            `json:"name,omitempty"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Container  *ContainerInfo    `json:"container,omitempty"`
+}
+
+type ContainerLogEntry struct {
+	Container string    `json:"container"`
+	Stream    string    `json:"stream"`
+	Timestamp time.Time `json:"timestamp,omitempty"`
+	Message   string    `json:"message"`
 }
 
 type BatteryInfo struct {
@@ -136,6 +504,19 @@ type NetworkUsageInfo struct {
 	Timestamp       time.Time `json:"timestamp,omitempty"`
 }
 
+type DiskIOInfo struct {
+	Device      string    `json:"device"`
+	ReadBytes   uint64    `json:"read_bytes"`
+	WriteBytes  uint64    `json:"write_bytes"`
+	ReadRate    float64   `json:"read_mb_per_sec"`
+	WriteRate   float64   `json:"write_mb_per_sec"`
+	ReadIOPS    float64   `json:"read_iops"`
+	WriteIOPS   float64   `json:"write_iops"`
+	AvgAwait    float64   `json:"avg_await_ms"`
+	Utilization float64   `json:"util_percent"`
+	Timestamp   time.Time `json:"timestamp,omitempty"`
+}
+
 type AlertConfig struct {
 	Enabled        bool    `json:"enabled"`
 	CPUWarning     float64 `json:"cpu_warning_threshold"`
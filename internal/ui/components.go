@@ -85,3 +85,44 @@ func RenderProcessTable(processes [][]string, width int, vertChar string) {
         }
     }
 }
+
+// RenderTable aligns rows into padded columns and returns one line per row.
+// The first row is treated as the header and colored accordingly.
+func RenderTable(rows [][]string) []string {
+    if len(rows) == 0 {
+        return []string{}
+    }
+
+    // Calculate column widths from the visible length of each cell
+    colWidths := []int{}
+    for _, row := range rows {
+        for i, col := range row {
+            if i >= len(colWidths) {
+                colWidths = append(colWidths, 0)
+            }
+            if w := RuneDisplayLength(col); w > colWidths[i] {
+                colWidths[i] = w
+            }
+        }
+    }
+
+    lines := make([]string, 0, len(rows))
+    for idx, row := range rows {
+        var sb strings.Builder
+        for i, col := range row {
+            cell := col
+            if idx == 0 {
+                cell = LabelColor(col)
+            }
+            sb.WriteString(cell)
+
+            // Pad every column except the last one
+            if i < len(row)-1 {
+                sb.WriteString(strings.Repeat(" ", colWidths[i]-RuneDisplayLength(col)+2))
+            }
+        }
+        lines = append(lines, sb.String())
+    }
+
+    return lines
+}
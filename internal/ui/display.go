@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/schollz/progressbar/v3"
 )
@@ -158,7 +159,21 @@ func RuneDisplayLength(s string) int {
     ansiRegex := regexp.MustCompile("\x1b\\[[0-9;]*m")
     cleanStr := ansiRegex.ReplaceAllString(s, "")
     
-    return len(cleanStr)
+    return utf8.RuneCountInString(cleanStr)
+}
+
+// FormatPercent colors a percentage using the same thresholds as the usage bars
+func FormatPercent(percentage float64) string {
+    text := fmt.Sprintf("%.1f%%", percentage)
+    
+    switch {
+    case percentage < 60:
+        return SuccessColor(text)
+    case percentage < 85:
+        return WarningColor(text)
+    default:
+        return DangerColor(text)
+    }
 }
 
 func FormatValueWithContext(key, value string) string {
@@ -191,17 +206,17 @@ func GetSectionIcon(sectionName string) string {
     switch sectionName {
     case "System", "Runtime Environment", "Runtime":
         return Info + " "
-    case "CPU":
+    case "CPU", "CPU Cores":
         return Cpu + " "
-    case "Memory":
+    case "Memory", "Swap":
         return Memory + " "
-    case "Disk":
+    case "Disk", "Disk I/O", "Disk I/O History":
         return Disk + " "
     case "Network":
         return Network + " "
-    case "Top Processes", "Processes":
+    case "Top Processes", "Top I/O", "Processes", "Process Tree", "Process Groups", "Process Health", "Process Detail", "Process Children":
         return "⏺ "
-    case "Docker", "Containers":
+    case "Docker", "Containers", "Compose Projects", "Docker Disk", "Docker Images", "Docker Volumes", "Docker Build Cache", "Container Health":
         return "🐳"
     default:
         return BulletPoint + " "
@@ -223,18 +238,43 @@ func getSortedSectionNames(sections map[string][][]string) []string {
 		"System":            1,
 		"Runtime Environment": 2,
 		"CPU":               3,
-		"Memory":            4,
-		"Disk":              5,
-		"Network":           6,
-		"Network Traffic":   7,
-		"Top Processes":     8,
-		"Processes":         9,
-		"Docker":            10,
-		"Containers":        11,
-		"Battery":           12,
-		"Temperature":       13,
-		"System Logs":       14,
-		"Resource History":  15,
+		"CPU Cores":         4,
+		"Memory":            5,
+		"Swap":              6,
+		"Disk":              7,
+		"Disk I/O":          8,
+		"Disk I/O History":  9,
+		"Network":           10,
+		"Network Traffic":   11,
+		"Top Processes":     12,
+		"Top I/O":           13,
+		"Processes":         14,
+		"Process Tree":      15,
+		"Process Groups":    16,
+		"Process Health":    17,
+		"Process Issues":    18,
+		"Process Detail":    19,
+		"Process Memory":    20,
+		"Process I/O":       21,
+		"Process Files":     22,
+		"Process Limits":    23,
+		"Process Namespaces": 24,
+		"Process Children":  25,
+		"Process Environment": 26,
+		"Docker":            27,
+		"Containers":        28,
+		"Compose Projects":  29,
+		"Docker Disk":       30,
+		"Docker Images":     31,
+		"Docker Volumes":    32,
+		"Docker Build Cache": 33,
+		"Container Health":  34,
+		"Container Issues":  35,
+		"Health Check Output": 36,
+		"Battery":           37,
+		"Temperature":       38,
+		"System Logs":       39,
+		"Resource History":  40,
 	}
 	
 	names := make([]string, 0, len(sections))
@@ -297,6 +337,15 @@ func printSectionData(data [][]string, termWidth int) {
 		return
 	}
 	
+	// Rows with more than two columns are tables with a header row
+	if len(data[0]) > 2 {
+		for _, line := range RenderTable(data) {
+			fmt.Printf("  %s\n", line)
+		}
+		fmt.Println()
+		return
+	}
+	
 	maxLabelWidth := 0
 	for _, row := range data {
 		if len(row) >= 2 && len(row[0]) > maxLabelWidth {
@@ -239,3 +239,60 @@ func RenderSparkline(data []float64, width int) string {
 	
 	return sb.String()
 }
+
+// RenderHeatGrid renders percentages as a grid of shaded, color-coded cells,
+// returning one line per row prefixed with the index of its first cell
+func RenderHeatGrid(data []float64, columns int) []string {
+	if len(data) == 0 {
+		return []string{"No data"}
+	}
+	
+	if columns < 1 {
+		columns = 1
+	}
+	
+	// Shade characters from idle to fully busy
+	shades := []string{"·", "░", "▒", "▓", "█"}
+	
+	// Width of the row label, based on the highest index
+	labelWidth := len(fmt.Sprintf("%d", len(data)-1))
+	
+	rows := make([]string, 0, (len(data)+columns-1)/columns)
+	for start := 0; start < len(data); start += columns {
+		end := start + columns
+		if end > len(data) {
+			end = len(data)
+		}
+		
+		var sb strings.Builder
+		sb.WriteString(DimColor(fmt.Sprintf("%*d ", labelWidth, start)))
+		
+		for _, val := range data[start:end] {
+			if val < 0 {
+				val = 0
+			} else if val > 100 {
+				val = 100
+			}
+			
+			idx := int(val / 100 * float64(len(shades)-1) + 0.5)
+			
+			// Match the thresholds used by the usage bars
+			var colorFunc func(...interface{}) string
+			switch {
+			case val < 60:
+				colorFunc = SuccessColor
+			case val < 85:
+				colorFunc = WarningColor
+			default:
+				colorFunc = DangerColor
+			}
+			
+			sb.WriteString(colorFunc(strings.Repeat(shades[idx], 2)))
+			sb.WriteString(" ")
+		}
+		
+		rows = append(rows, strings.TrimRight(sb.String(), " "))
+	}
+	
+	return rows
+}